@@ -2,6 +2,7 @@ package api
 
 import (
 	"reacher-cron/api/v1/health"
+	"reacher-cron/api/v1/heartbeat"
 	"reacher-cron/config"
 
 	"github.com/gin-contrib/cors"
@@ -34,5 +35,17 @@ func SetupRoutes(r *gin.Engine) {
 			healthApi.GET("", health.GetHealthCron)
 		}
 
+		heartbeatApi := v1.Group("/heartbeat")
+		{
+			heartbeatApi.GET("/:token", heartbeat.ReceiveHeartbeat)
+			heartbeatApi.POST("/:token", heartbeat.ReceiveHeartbeat)
+			heartbeatApi.GET("/:token/start", heartbeat.ReceiveJobStart)
+			heartbeatApi.POST("/:token/start", heartbeat.ReceiveJobStart)
+			heartbeatApi.GET("/:token/success", heartbeat.ReceiveJobSuccess)
+			heartbeatApi.POST("/:token/success", heartbeat.ReceiveJobSuccess)
+			heartbeatApi.GET("/:token/fail", heartbeat.ReceiveJobFailure)
+			heartbeatApi.POST("/:token/fail", heartbeat.ReceiveJobFailure)
+		}
+
 	}
 }
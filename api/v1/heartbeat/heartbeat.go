@@ -0,0 +1,78 @@
+package heartbeat
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	v1 "reacher-cron/services/v1"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReceiveHeartbeat registra o ping enviado por um job monitorado via heartbeat.
+func ReceiveHeartbeat(c *gin.Context) {
+	token := c.Param("token")
+
+	monitorID, err := v1.RecordHeartbeat(token)
+	respondHeartbeat(c, monitorID, err)
+}
+
+// respondHeartbeat converte o resultado do registro do ping em resposta HTTP.
+func respondHeartbeat(c *gin.Context, monitorID int, err error) {
+	if errors.Is(err, v1.ErrUnknownHeartbeatToken) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status":  http.StatusNotFound,
+			"message": "unknown heartbeat token",
+		})
+		return
+	} else if err != nil {
+		log.Printf("[HEARTBEAT] Error recording heartbeat: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  http.StatusInternalServerError,
+			"message": "error recording heartbeat",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    http.StatusOK,
+		"message":   "ok",
+		"monitorId": monitorID,
+	})
+}
+
+// ReceiveJobStart registra o início de uma execução de cron job.
+func ReceiveJobStart(c *gin.Context) {
+	monitorID, err := v1.RecordJobStart(c.Param("token"))
+	respondHeartbeat(c, monitorID, err)
+}
+
+// ReceiveJobSuccess registra o fim de uma execução com sucesso (exitCode opcional, padrão 0).
+func ReceiveJobSuccess(c *gin.Context) {
+	receiveJobFinish(c, 0)
+}
+
+// ReceiveJobFailure registra o fim de uma execução com falha (exitCode opcional, padrão 1).
+func ReceiveJobFailure(c *gin.Context) {
+	receiveJobFinish(c, 1)
+}
+
+func receiveJobFinish(c *gin.Context, defaultExitCode int) {
+	exitCode := defaultExitCode
+	if v := c.Query("exitCode"); v != "" {
+		code, err := strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  http.StatusBadRequest,
+				"message": "invalid exitCode",
+			})
+			return
+		}
+		exitCode = code
+	}
+
+	monitorID, err := v1.RecordJobFinish(c.Param("token"), exitCode)
+	respondHeartbeat(c, monitorID, err)
+}
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"net"
+	"net/http"
+	"reacher-cron/config"
+	"sync"
+	"time"
+)
+
+var (
+	httpClient *http.Client
+	hOnce      sync.Once
+)
+
+// GetHTTPClient retorna o cliente HTTP compartilhado pelos health checks.
+// O timeout de cada requisição é aplicado via context, por monitor; por isso o
+// transport não limita conexão nem handshake TLS, o que cortaria monitores com
+// timeout maior que o padrão global.
+func GetHTTPClient() *http.Client {
+	hOnce.Do(func() {
+		transport := &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          config.AppConfig.HTTPMaxIdleConns,
+			MaxIdleConnsPerHost:   config.AppConfig.HTTPMaxIdleConnsPerHost,
+			IdleConnTimeout:       config.AppConfig.HTTPIdleConnTimeout,
+			ExpectContinueTimeout: 1 * time.Second,
+		}
+		httpClient = &http.Client{Transport: transport}
+	})
+	return httpClient
+}
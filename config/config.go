@@ -3,6 +3,8 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -11,6 +13,34 @@ type Config struct {
 	Port        string
 	PostgresURI string
 	RedisURI    string
+
+	// Cliente HTTP compartilhado pelos health checks
+	HTTPTimeout             time.Duration // Timeout padrão quando o monitor não define o seu
+	HTTPMaxIdleConns        int
+	HTTPMaxIdleConnsPerHost int
+	HTTPIdleConnTimeout     time.Duration
+	HTTPMaxBodyBytes        int64 // Limite de leitura do corpo para asserções
+
+	// Quantidade padrão de checks nas janelas de taxa de falhas e de latência
+	FailureWindow int
+	LatencyWindow int
+
+	// Novas tentativas padrão quando o monitor não define as suas
+	CheckRetries      int
+	CheckRetryBackoff time.Duration
+
+	// Tabela global de classificação por código HTTP, em JSON
+	// (ex.: {"429":"service_degraded","503":"partial_outage"}).
+	StatusCodeClassification string
+
+	// Diretório com os plugins que monitores do tipo command podem executar;
+	// vazio desabilita esse tipo de monitor
+	CheckCommandDir string
+
+	// Dias restantes até a expiração do certificado para cada classificação
+	CertDegradedDays      int
+	CertPartialOutageDays int
+	CertMajorOutageDays   int
 }
 
 var AppConfig *Config
@@ -25,6 +55,26 @@ func LoadConfig() {
 		Port:        getEnv("PORT", "8081"),
 		PostgresURI: getEnv("POSTGRES_URI", "localhost"),
 		RedisURI:    getEnv("REDIS_URI", "localhost"),
+
+		HTTPTimeout:             time.Duration(getEnvInt("HTTP_TIMEOUT_MS", 5000)) * time.Millisecond,
+		HTTPMaxIdleConns:        getEnvInt("HTTP_MAX_IDLE_CONNS", 100),
+		HTTPMaxIdleConnsPerHost: getEnvInt("HTTP_MAX_IDLE_CONNS_PER_HOST", 10),
+		HTTPIdleConnTimeout:     time.Duration(getEnvInt("HTTP_IDLE_CONN_TIMEOUT_S", 90)) * time.Second,
+		HTTPMaxBodyBytes:        int64(getEnvInt("HTTP_MAX_BODY_BYTES", 1<<20)),
+
+		FailureWindow: getEnvInt("FAILURE_WINDOW", 10),
+		LatencyWindow: getEnvInt("LATENCY_WINDOW", 5),
+
+		CheckRetries:      getEnvInt("CHECK_RETRIES", 0),
+		CheckRetryBackoff: time.Duration(getEnvInt("CHECK_RETRY_BACKOFF_MS", 1000)) * time.Millisecond,
+
+		StatusCodeClassification: getEnv("STATUS_CODE_CLASSIFICATION", ""),
+
+		CheckCommandDir: getEnv("CHECK_COMMAND_DIR", ""),
+
+		CertDegradedDays:      getEnvInt("CERT_DEGRADED_DAYS", 30),
+		CertPartialOutageDays: getEnvInt("CERT_PARTIAL_OUTAGE_DAYS", 14),
+		CertMajorOutageDays:   getEnvInt("CERT_MAJOR_OUTAGE_DAYS", 3),
 	}
 }
 
@@ -35,3 +85,16 @@ func getEnv(key string, defaultValue string) string {
 	}
 	return value
 }
+
+func getEnvInt(key string, defaultValue int) int {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+	num, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Invalid value for %s (%q), using default %d", key, value, defaultValue)
+		return defaultValue
+	}
+	return num
+}
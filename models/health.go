@@ -27,4 +27,5 @@ const (
 	ServiceDegraded Status = "service_degraded" // Service is degraded; failure rate exceeds the minimal threshold.
 	PartialOutage   Status = "partial_outage"   // Partial outage; failure rate is between the degraded and critical thresholds.
 	MajorOutage     Status = "major_outage"     // Major outage; failure rate meets or exceeds the critical threshold.
+	Unknown         Status = "unknown"          // Check could not determine the state (e.g. plugin UNKNOWN); no incident is opened.
 )
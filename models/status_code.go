@@ -0,0 +1,27 @@
+package models
+
+// StatusCodeRange é um intervalo inclusivo de códigos HTTP (ex.: 200-299).
+type StatusCodeRange struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// StatusCodeSpec é a lista de códigos HTTP aceitos por um monitor,
+// montada a partir de especificações como "2xx", "200-299" ou "200,201,204".
+type StatusCodeSpec []StatusCodeRange
+
+// Matches indica se o código está em algum dos intervalos.
+func (s StatusCodeSpec) Matches(code int) bool {
+	for _, r := range s {
+		if code >= r.Min && code <= r.Max {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusCodeRule associa códigos HTTP inesperados a uma classificação de status.
+type StatusCodeRule struct {
+	Codes  StatusCodeSpec `json:"codes"`
+	Status Status         `json:"status"`
+}
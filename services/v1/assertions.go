@@ -0,0 +1,229 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"reacher-cron/models"
+)
+
+// evaluateBodyAssertions valida o corpo da resposta contra as asserções do monitor.
+// Retorna o erro da primeira asserção que falhar.
+func evaluateBodyAssertions(m models.Monitor, body []byte) error {
+	for _, term := range m.BodyContains {
+		if !bytes.Contains(body, []byte(term)) {
+			return fmt.Errorf("body does not contain %q", term)
+		}
+	}
+
+	for _, term := range m.BodyNotContains {
+		if bytes.Contains(body, []byte(term)) {
+			return fmt.Errorf("body contains forbidden %q", term)
+		}
+	}
+
+	if m.BodyRegex != "" {
+		// mapToMonitor já compila a expressão; sem ela (ex.: monitor montado em código), compila aqui.
+		re := m.BodyPattern
+		if re == nil {
+			var err error
+			if re, err = regexp.Compile(m.BodyRegex); err != nil {
+				return fmt.Errorf("invalid body regex %q: %v", m.BodyRegex, err)
+			}
+		}
+		if !re.Match(body) {
+			return fmt.Errorf("body does not match regex %q", m.BodyRegex)
+		}
+	}
+
+	return nil
+}
+
+// jsonAssertionResult é o resultado de uma asserção, gravado no histórico.
+type jsonAssertionResult struct {
+	Path     string      `json:"path"`
+	Operator string      `json:"operator"`
+	Expected string      `json:"expected,omitempty"`
+	Actual   interface{} `json:"actual,omitempty"`
+	Passed   bool        `json:"passed"`
+	Error    string      `json:"error,omitempty"`
+}
+
+// evaluateJSONAssertions avalia todas as asserções contra o corpo JSON.
+// Todas são avaliadas para o histórico; o erro retornado é o da primeira que falhou.
+func evaluateJSONAssertions(assertions []models.JSONAssertion, body []byte) ([]jsonAssertionResult, error) {
+	results := make([]jsonAssertionResult, 0, len(assertions))
+
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		for _, a := range assertions {
+			results = append(results, jsonAssertionResult{Path: a.Path, Operator: a.Operator, Expected: a.Value, Error: "invalid JSON body"})
+		}
+		return results, fmt.Errorf("response body is not valid JSON: %v", err)
+	}
+
+	var firstErr error
+	for _, a := range assertions {
+		r := jsonAssertionResult{Path: a.Path, Operator: a.Operator, Expected: a.Value}
+
+		actual, found, err := lookupJSONPath(doc, a.Path)
+		if err == nil {
+			r.Actual = actual
+			r.Passed, err = compareJSONValue(actual, found, a.Operator, a.Value)
+		}
+		if err != nil {
+			r.Error = err.Error()
+		}
+
+		if !r.Passed && firstErr == nil {
+			if err != nil {
+				firstErr = fmt.Errorf("assertion %s %s %s: %v", a.Path, a.Operator, a.Value, err)
+			} else {
+				firstErr = fmt.Errorf("assertion %s %s %s failed (actual: %v)", a.Path, a.Operator, a.Value, actual)
+			}
+		}
+		results = append(results, r)
+	}
+
+	return results, firstErr
+}
+
+// lookupJSONPath resolve um path simples no formato $.a.b[0].c.
+func lookupJSONPath(doc interface{}, path string) (interface{}, bool, error) {
+	p := strings.TrimSpace(path)
+	p = strings.TrimPrefix(p, "$")
+
+	current := doc
+	for p != "" {
+		switch p[0] {
+		case '.':
+			p = p[1:]
+			end := strings.IndexAny(p, ".[")
+			if end == -1 {
+				end = len(p)
+			}
+			key := p[:end]
+			p = p[end:]
+			if key == "" {
+				return nil, false, fmt.Errorf("invalid path %q", path)
+			}
+			obj, ok := current.(map[string]interface{})
+			if !ok {
+				return nil, false, nil
+			}
+			if current, ok = obj[key]; !ok {
+				return nil, false, nil
+			}
+		case '[':
+			end := strings.Index(p, "]")
+			if end == -1 {
+				return nil, false, fmt.Errorf("invalid path %q", path)
+			}
+			token := p[1:end]
+			p = p[end+1:]
+
+			// Suporta tanto índices ([0]) quanto chaves entre aspas (['chave']).
+			if key := strings.Trim(token, `'"`); key != token {
+				obj, ok := current.(map[string]interface{})
+				if !ok {
+					return nil, false, nil
+				}
+				if current, ok = obj[key]; !ok {
+					return nil, false, nil
+				}
+				continue
+			}
+			idx, err := strconv.Atoi(token)
+			if err != nil {
+				return nil, false, fmt.Errorf("invalid index %q in path %q", token, path)
+			}
+			arr, ok := current.([]interface{})
+			if !ok || idx < 0 || idx >= len(arr) {
+				return nil, false, nil
+			}
+			current = arr[idx]
+		default:
+			return nil, false, fmt.Errorf("invalid path %q", path)
+		}
+	}
+
+	return current, true, nil
+}
+
+// compareJSONValue aplica o operador entre o valor encontrado e o esperado.
+// Comparações numéricas são usadas quando os dois lados são números.
+func compareJSONValue(actual interface{}, found bool, operator, expected string) (bool, error) {
+	if operator == "exists" {
+		return found, nil
+	}
+	if !found {
+		return false, fmt.Errorf("path not found")
+	}
+
+	expected = strings.Trim(strings.TrimSpace(expected), `"`)
+
+	switch operator {
+	case "contains":
+		if arr, ok := actual.([]interface{}); ok {
+			for _, item := range arr {
+				if jsonValueString(item) == expected {
+					return true, nil
+				}
+			}
+			return false, nil
+		}
+		return strings.Contains(jsonValueString(actual), expected), nil
+	case "==", "!=", "<", "<=", ">", ">=":
+	default:
+		return false, fmt.Errorf("unsupported operator %q", operator)
+	}
+
+	if num, ok := actual.(float64); ok {
+		if want, err := strconv.ParseFloat(expected, 64); err == nil {
+			switch operator {
+			case "==":
+				return num == want, nil
+			case "!=":
+				return num != want, nil
+			case "<":
+				return num < want, nil
+			case "<=":
+				return num <= want, nil
+			case ">":
+				return num > want, nil
+			case ">=":
+				return num >= want, nil
+			}
+		}
+	}
+
+	got := jsonValueString(actual)
+	switch operator {
+	case "==":
+		return got == expected, nil
+	case "!=":
+		return got != expected, nil
+	}
+	return false, fmt.Errorf("operator %q requires numeric values", operator)
+}
+
+// jsonValueString converte um valor JSON decodificado para string comparável.
+func jsonValueString(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	default:
+		raw, _ := json.Marshal(val)
+		return string(raw)
+	}
+}
@@ -0,0 +1,141 @@
+package v1
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"reacher-cron/models"
+)
+
+func TestLookupJSONPath(t *testing.T) {
+	var doc interface{}
+	raw := `{
+		"status": "ok",
+		"db": {"latency": 12.5, "up": true, "replica": null},
+		"items": [{"id": 1, "tags": ["a", "b"]}, {"id": 2}],
+		"weird key": {"x.y": "dotted"}
+	}`
+	if err := json.Unmarshal([]byte(raw), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		path      string
+		want      interface{}
+		wantFound bool
+		wantErr   bool
+	}{
+		{"root", "$", doc, true, false},
+		{"top-level key", "$.status", "ok", true, false},
+		{"without dollar", ".status", "ok", true, false},
+		{"nested key", "$.db.latency", 12.5, true, false},
+		{"boolean", "$.db.up", true, true, false},
+		{"null value is found", "$.db.replica", nil, true, false},
+		{"array index", "$.items[1].id", float64(2), true, false},
+		{"nested array", "$.items[0].tags[1]", "b", true, false},
+		{"quoted key", "$['weird key']['x.y']", "dotted", true, false},
+		{"double-quoted key", `$["weird key"]["x.y"]`, "dotted", true, false},
+		{"missing key", "$.db.missing", nil, false, false},
+		{"index out of range", "$.items[5]", nil, false, false},
+		{"negative index", "$.items[-1]", nil, false, false},
+		{"index on object", "$.db[0]", nil, false, false},
+		{"key on array", "$.items.id", nil, false, false},
+		{"empty segment", "$..status", nil, false, true},
+		{"unclosed bracket", "$.items[0", nil, false, true},
+		{"non-numeric index", "$.items[x]", nil, false, true},
+		{"missing separator", "$status", nil, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found, err := lookupJSONPath(doc, tt.path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("lookupJSONPath(%q) = %v, want error", tt.path, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("lookupJSONPath(%q) unexpected error: %v", tt.path, err)
+			}
+			if found != tt.wantFound {
+				t.Fatalf("lookupJSONPath(%q) found = %v, want %v", tt.path, found, tt.wantFound)
+			}
+			if found && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("lookupJSONPath(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareJSONValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		actual   interface{}
+		found    bool
+		operator string
+		expected string
+		want     bool
+		wantErr  bool
+	}{
+		{"exists found", "x", true, "exists", "", true, false},
+		{"exists missing", nil, false, "exists", "", false, false},
+		{"numeric equal", 12.0, true, "==", "12", true, false},
+		{"numeric less", 12.5, true, "<", "20", true, false},
+		{"numeric greater fails", 12.5, true, ">", "20", false, false},
+		{"string equal with quotes", "ok", true, "==", `"ok"`, true, false},
+		{"string not equal", "ok", true, "!=", "down", true, false},
+		{"bool equal", true, true, "==", "true", true, false},
+		{"contains substring", "all good", true, "contains", "good", true, false},
+		{"contains array item", []interface{}{"a", "b"}, true, "contains", "b", true, false},
+		{"missing path", nil, false, "==", "1", false, true},
+		{"ordering on strings", "abc", true, "<", "b", false, true},
+		{"unknown operator", 1.0, true, "~", "1", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := compareJSONValue(tt.actual, tt.found, tt.operator, tt.expected)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("compareJSONValue() = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("compareJSONValue() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("compareJSONValue(%v %s %q) = %v, want %v", tt.actual, tt.operator, tt.expected, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateBodyAssertions(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       models.Monitor
+		body    string
+		wantErr bool
+	}{
+		{"contains", models.Monitor{BodyContains: []string{"ok"}}, "status ok", false},
+		{"missing term", models.Monitor{BodyContains: []string{"ok"}}, "status down", true},
+		{"forbidden term", models.Monitor{BodyNotContains: []string{"error"}}, "an error", true},
+		{"regex compiled on demand", models.Monitor{BodyRegex: `^v\d+`}, "v12", false},
+		{"cached pattern", models.Monitor{BodyRegex: `^v\d+`, BodyPattern: regexp.MustCompile(`^v\d+`)}, "x12", true},
+		{"invalid regex", models.Monitor{BodyRegex: `(`}, "anything", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := evaluateBodyAssertions(tt.m, []byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("evaluateBodyAssertions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
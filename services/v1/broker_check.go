@@ -0,0 +1,306 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"reacher-cron/client"
+	"reacher-cron/models"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/nats-io/nats.go"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func init() {
+	RegisterChecker(models.MonitorTypeAMQP, CheckerFunc(runAMQPCheck))
+	RegisterChecker(models.MonitorTypeNATS, CheckerFunc(runNATSCheck))
+	RegisterChecker(models.MonitorTypeMQTT, CheckerFunc(runMQTTCheck))
+}
+
+// Subject/tópico padrão da sonda; no AMQP o padrão é uma fila temporária nomeada pelo servidor.
+const (
+	defaultNATSSubject = "reacher-cron.probe"
+	defaultMQTTTopic   = "reacher-cron/probe"
+)
+
+// Espera pelo cancelamento da assinatura MQTT, que acontece depois do timeout do check.
+const brokerCleanupTimeout = time.Second
+
+// Etapas da sonda de broker; cada uma tem seu próprio reason no histórico.
+// setup cobre a preparação do consumo (fila, assinatura, modo de confirmação).
+const (
+	brokerStageConnect = "connect"
+	brokerStageSetup   = "setup"
+	brokerStagePublish = "publish"
+	brokerStageConsume = "consume"
+)
+
+// brokerProbe acompanha uma execução: a mensagem única publicada e a duração das etapas.
+type brokerProbe struct {
+	m         models.Monitor
+	payload   string
+	startTime time.Time
+	deadline  time.Time
+	timings   map[string]int64
+	mark      time.Time
+}
+
+func newBrokerProbe(m models.Monitor) *brokerProbe {
+	now := time.Now().UTC()
+	return &brokerProbe{
+		m:         m,
+		payload:   fmt.Sprintf("reacher-cron probe %d-%d", m.ID, now.UnixNano()),
+		startTime: now,
+		deadline:  now.Add(monitorTimeout(m)),
+		timings:   map[string]int64{},
+		mark:      now,
+	}
+}
+
+// done registra a duração da etapa desde a marca anterior.
+func (p *brokerProbe) done(stage string) {
+	p.timings[stage] = time.Since(p.mark).Milliseconds()
+	p.mark = time.Now()
+}
+
+func (p *brokerProbe) remaining() time.Duration {
+	return time.Until(p.deadline)
+}
+
+// fail monta o resultado de falha indicando a etapa em que a sonda parou.
+func (p *brokerProbe) fail(stage, topic string, err error) CheckResult {
+	reason := reasonConnectionError
+	switch stage {
+	case brokerStageSetup:
+		reason = reasonSetupFailed
+	case brokerStagePublish:
+		reason = reasonPublishFailed
+	case brokerStageConsume:
+		reason = reasonConsumeFailed
+	}
+
+	log.Printf("[HEALTH] Monitor %s (ID: %d) %s probe failed at %s (%s): %v", p.m.Name, p.m.ID, p.m.Type, stage, reason, err)
+	return CheckResult{
+		Status:   models.MajorOutage,
+		Latency:  time.Since(p.startTime),
+		Reason:   reason,
+		Error:    fmt.Sprintf("%s: %v", stage, err),
+		Timings:  p.timings,
+		Evidence: map[string]interface{}{"stage": stage, "topic": topic},
+	}
+}
+
+// success usa como latência o tempo entre a publicação e o recebimento da sonda.
+func (p *brokerProbe) success(topic string, publishedAt time.Time) CheckResult {
+	roundTrip := time.Since(publishedAt)
+	p.timings["roundtrip"] = roundTrip.Milliseconds()
+	return CheckResult{
+		Status:   models.Operational,
+		Latency:  roundTrip,
+		Timings:  p.timings,
+		Evidence: map[string]interface{}{"topic": topic},
+	}
+}
+
+// runAMQPCheck publica a sonda com confirmação do broker e consome a mensagem de volta.
+// Uma fila nomeada em BrokerTopic precisa já existir: ela é verificada de forma passiva
+// (qualquer durabilidade) e o consumo confirma só a sonda. Outras mensagens ficam sem ack
+// e voltam para a fila quando o canal é fechado.
+func runAMQPCheck(m models.Monitor) CheckResult {
+	probe := newBrokerProbe(m)
+	queue := m.BrokerTopic
+
+	conn, err := amqp.DialConfig(m.URL, amqp.Config{Dial: amqp.DefaultDial(probe.remaining())})
+	if err != nil {
+		return probe.fail(brokerStageConnect, queue, err)
+	}
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return probe.fail(brokerStageConnect, queue, err)
+	}
+	defer ch.Close()
+	probe.done(brokerStageConnect)
+
+	// Sem fila configurada, usa uma fila exclusiva que some ao desconectar.
+	var q amqp.Queue
+	if queue == "" {
+		q, err = ch.QueueDeclare("", false, true, true, false, nil)
+	} else {
+		q, err = ch.QueueDeclarePassive(queue, false, false, false, false, nil)
+	}
+	if err != nil {
+		return probe.fail(brokerStageSetup, queue, err)
+	}
+	deliveries, err := ch.Consume(q.Name, "", false, false, false, false, nil)
+	if err != nil {
+		return probe.fail(brokerStageSetup, q.Name, err)
+	}
+
+	if err := ch.Confirm(false); err != nil {
+		return probe.fail(brokerStageSetup, q.Name, err)
+	}
+
+	ctx, cancel := context.WithDeadline(client.Ctx, probe.deadline)
+	defer cancel()
+
+	probe.done(brokerStageSetup)
+	publishedAt := probe.mark
+	confirmation, err := ch.PublishWithDeferredConfirmWithContext(ctx, "", q.Name, false, false, amqp.Publishing{
+		ContentType: "text/plain",
+		Body:        []byte(probe.payload),
+	})
+	if err != nil {
+		return probe.fail(brokerStagePublish, q.Name, err)
+	}
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return probe.fail(brokerStagePublish, q.Name, err)
+	}
+	if !acked {
+		return probe.fail(brokerStagePublish, q.Name, fmt.Errorf("broker rejected the probe message"))
+	}
+	probe.done(brokerStagePublish)
+
+	for {
+		select {
+		case d, ok := <-deliveries:
+			if !ok {
+				return probe.fail(brokerStageConsume, q.Name, fmt.Errorf("delivery channel closed"))
+			}
+			if string(d.Body) == probe.payload {
+				if err := d.Ack(false); err != nil {
+					return probe.fail(brokerStageConsume, q.Name, err)
+				}
+				probe.done(brokerStageConsume)
+				return probe.success(q.Name, publishedAt)
+			}
+		case <-ctx.Done():
+			return probe.fail(brokerStageConsume, q.Name, fmt.Errorf("probe message not received: %v", ctx.Err()))
+		}
+	}
+}
+
+// runNATSCheck assina o subject da sonda, publica e espera a mensagem voltar.
+func runNATSCheck(m models.Monitor) CheckResult {
+	probe := newBrokerProbe(m)
+	subject := m.BrokerTopic
+	if subject == "" {
+		subject = defaultNATSSubject
+	}
+
+	nc, err := nats.Connect(m.URL, nats.Name("reacher-cron"), nats.Timeout(probe.remaining()), nats.NoReconnect())
+	if err != nil {
+		return probe.fail(brokerStageConnect, subject, err)
+	}
+	defer nc.Close()
+	probe.done(brokerStageConnect)
+
+	sub, err := nc.SubscribeSync(subject)
+	if err != nil {
+		return probe.fail(brokerStageSetup, subject, err)
+	}
+	defer sub.Unsubscribe()
+	// Garante que a assinatura chegou ao servidor antes de publicar.
+	if err := nc.FlushTimeout(probe.remaining()); err != nil {
+		return probe.fail(brokerStageSetup, subject, err)
+	}
+
+	probe.done(brokerStageSetup)
+	publishedAt := probe.mark
+	if err := nc.Publish(subject, []byte(probe.payload)); err != nil {
+		return probe.fail(brokerStagePublish, subject, err)
+	}
+	if err := nc.FlushTimeout(probe.remaining()); err != nil {
+		return probe.fail(brokerStagePublish, subject, err)
+	}
+	probe.done(brokerStagePublish)
+
+	for {
+		msg, err := sub.NextMsg(probe.remaining())
+		if err != nil {
+			return probe.fail(brokerStageConsume, subject, fmt.Errorf("probe message not received: %v", err))
+		}
+		if string(msg.Data) == probe.payload {
+			probe.done(brokerStageConsume)
+			return probe.success(subject, publishedAt)
+		}
+	}
+}
+
+// runMQTTCheck assina o tópico da sonda com QoS 1, publica e espera a mensagem voltar.
+// Esquemas aceitos na URL: mqtt/tcp, mqtts/ssl/tls e ws/wss.
+func runMQTTCheck(m models.Monitor) CheckResult {
+	probe := newBrokerProbe(m)
+	topic := m.BrokerTopic
+	if topic == "" {
+		topic = defaultMQTTTopic
+	}
+
+	opts := mqtt.NewClientOptions().
+		AddBroker(m.URL).
+		SetClientID(fmt.Sprintf("reacher-cron-%d-%d", m.ID, probe.startTime.UnixNano())).
+		SetCleanSession(true).
+		SetAutoReconnect(false).
+		SetConnectRetry(false).
+		SetConnectTimeout(probe.remaining())
+	if u, err := url.Parse(m.URL); err == nil && u.User != nil {
+		password, _ := u.User.Password()
+		opts.SetUsername(u.User.Username()).SetPassword(password)
+	}
+
+	c := mqtt.NewClient(opts)
+	if err := waitMQTT(c.Connect(), probe.remaining()); err != nil {
+		return probe.fail(brokerStageConnect, topic, err)
+	}
+	defer c.Disconnect(250)
+	probe.done(brokerStageConnect)
+
+	received := make(chan struct{}, 1)
+	subscription := c.Subscribe(topic, 1, func(_ mqtt.Client, msg mqtt.Message) {
+		if string(msg.Payload()) == probe.payload {
+			select {
+			case received <- struct{}{}:
+			default:
+			}
+		}
+	})
+	if err := waitMQTT(subscription, probe.remaining()); err != nil {
+		return probe.fail(brokerStageSetup, topic, err)
+	}
+	// Espera o UNSUBACK antes do Disconnect adiado acima, senão o cancelamento pode não
+	// chegar ao broker.
+	defer func() {
+		if err := waitMQTT(c.Unsubscribe(topic), brokerCleanupTimeout); err != nil {
+			log.Printf("[HEALTH] Monitor %s (ID: %d) MQTT unsubscribe from %s failed: %v", m.Name, m.ID, topic, err)
+		}
+	}()
+
+	probe.done(brokerStageSetup)
+	publishedAt := probe.mark
+	if err := waitMQTT(c.Publish(topic, 1, false, probe.payload), probe.remaining()); err != nil {
+		return probe.fail(brokerStagePublish, topic, err)
+	}
+	probe.done(brokerStagePublish)
+
+	select {
+	case <-received:
+		probe.done(brokerStageConsume)
+		return probe.success(topic, publishedAt)
+	case <-time.After(probe.remaining()):
+		return probe.fail(brokerStageConsume, topic, fmt.Errorf("probe message not received before timeout"))
+	}
+}
+
+// waitMQTT espera o token da operação MQTT dentro do tempo restante.
+func waitMQTT(token mqtt.Token, timeout time.Duration) error {
+	if !token.WaitTimeout(timeout) {
+		return fmt.Errorf("operation timed out")
+	}
+	return token.Error()
+}
@@ -0,0 +1,112 @@
+package v1
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"reacher-cron/models"
+)
+
+// Motivos de falha registrados no histórico.
+const (
+	reasonTimeout            = "timeout"
+	reasonConnectionError    = "connection_error"
+	reasonUnexpectedStatus   = "unexpected_status"
+	reasonAssertionFailed    = "assertion_failed"
+	reasonUnexpectedResponse = "unexpected_response"
+	reasonResolutionFailed   = "resolution_failed"
+	reasonCertInvalid        = "certificate_invalid"
+	reasonCertExpiring       = "certificate_expiring"
+	reasonUnsupportedType    = "unsupported_type"
+	reasonNotServing         = "not_serving"
+	reasonMissedHeartbeat    = "missed_heartbeat"
+	reasonLateStart          = "late_start"
+	reasonOverrun            = "overrun"
+	reasonJobFailed          = "job_failed"
+	reasonQueryFailed        = "query_failed"
+	reasonExtractionFailed   = "extraction_failed"
+	reasonPluginWarning      = "plugin_warning"
+	reasonPluginCritical     = "plugin_critical"
+	reasonPluginUnknown      = "plugin_unknown"
+	reasonAuthFailed         = "auth_failed"
+	reasonDeliveryFailed     = "delivery_failed"
+	reasonHostKeyChanged     = "host_key_changed"
+	reasonSetupFailed        = "setup_failed"
+	reasonPublishFailed      = "publish_failed"
+	reasonConsumeFailed      = "consume_failed"
+	reasonInvalidConfig      = "invalid_config"
+	reasonSlowResponse       = "slow_response"
+)
+
+// CheckResult é o resultado comum de qualquer tipo de check. É gravado no
+// histórico por registerStateHistoryAndMetrics e alimenta as regras de incidente.
+type CheckResult struct {
+	Status   models.Status
+	Latency  time.Duration
+	Reason   string                 // Motivo da falha; vazio quando operacional
+	Error    string                 // Mensagem de erro da falha
+	Evidence map[string]interface{} // Dados extras gravados no histórico
+	Timings  map[string]int64       // Duração de cada fase em ms (ex.: dns, connect, tls)
+	Metrics  map[string]float64     // Métricas numéricas do check (ex.: perfdata de plugins)
+	Attempts int                    // Tentativas feitas nesta execução
+}
+
+// Checker executa o check de um tipo de monitor (http, tcp, dns...).
+// Novos protocolos implementam Checker e se registram com RegisterChecker,
+// sem alterar agendamento, histórico ou regras de incidente.
+type Checker interface {
+	Check(m models.Monitor) CheckResult
+}
+
+// CheckerFunc permite usar uma função comum como Checker.
+type CheckerFunc func(m models.Monitor) CheckResult
+
+func (f CheckerFunc) Check(m models.Monitor) CheckResult {
+	return f(m)
+}
+
+var (
+	checkers   = make(map[string]Checker)
+	checkersMu sync.RWMutex
+)
+
+// RegisterChecker associa um Checker ao tipo de monitor. Registrar o mesmo tipo
+// duas vezes substitui o anterior.
+func RegisterChecker(monitorType string, checker Checker) {
+	checkersMu.Lock()
+	defer checkersMu.Unlock()
+	checkers[monitorType] = checker
+}
+
+// runCheck executa o Checker registrado para o tipo do monitor.
+func runCheck(m models.Monitor) CheckResult {
+	monitorType := m.Type
+	if monitorType == "" {
+		monitorType = models.MonitorTypeHTTP
+	}
+
+	// Monitor mal configurado: não roda o check para não reportar sucesso sem a
+	// configuração pedida; fica como unknown no histórico.
+	if len(m.ConfigErrors) > 0 {
+		return CheckResult{
+			Status: models.Unknown,
+			Reason: reasonInvalidConfig,
+			Error:  strings.Join(m.ConfigErrors, "; "),
+		}
+	}
+
+	checkersMu.RLock()
+	checker, ok := checkers[monitorType]
+	checkersMu.RUnlock()
+
+	if !ok {
+		return CheckResult{
+			Status: models.MajorOutage,
+			Reason: reasonUnsupportedType,
+			Error:  fmt.Sprintf("unsupported monitor type %q", monitorType),
+		}
+	}
+	return checker.Check(m)
+}
@@ -0,0 +1,167 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"reacher-cron/client"
+	"reacher-cron/config"
+	"reacher-cron/models"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// historyEntry é o subconjunto de campos do histórico usado na classificação.
+type historyEntry struct {
+	Status       models.Status `json:"status"`
+	Reason       string        `json:"reason"`
+	ResponseTime int64         `json:"responseTime"`
+}
+
+// responded informa se o check teve sucesso, ainda que rebaixado por latência.
+func (e historyEntry) responded() bool {
+	return e.Status == models.Operational || e.Reason == reasonSlowResponse
+}
+
+// recentHistory lê os últimos n registros de monitor:<id>:history (o mais recente por último).
+func recentHistory(m models.Monitor, n int, rdb *redis.Client) ([]historyEntry, error) {
+	raw, err := rdb.LRange(client.Ctx, monitorHistoryKey(m.ID), int64(-n), -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]historyEntry, 0, len(raw))
+	for _, item := range raw {
+		var entry historyEntry
+		if err := json.Unmarshal([]byte(item), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// classifyFailureRate calcula a porcentagem de falhas nos últimos checks do monitor
+// e aplica os thresholds documentados em models/health.go:
+//   - taxa >= MajorOutageThreshold     => major_outage
+//   - taxa >= PartialOutageThreshold   => partial_outage
+//   - taxa >= ServiceDegradedThreshold => service_degraded
+//
+// O resultado é limitado ao pior status da janela. Abaixo de todos os thresholds
+// retorna operational (nenhum incidente é aberto).
+// O segundo retorno descreve a taxa calculada, usado como evidência do incidente.
+func classifyFailureRate(m models.Monitor, rdb *redis.Client) (models.Status, string) {
+	window := config.AppConfig.FailureWindow
+	if m.FailureWindow != nil && *m.FailureWindow > 0 {
+		window = *m.FailureWindow
+	}
+
+	entries, err := recentHistory(m, window, rdb)
+	if err != nil || len(entries) == 0 {
+		// Sem histórico não há como calcular a taxa; mantém o comportamento de falha imediata.
+		log.Printf("[HEALTH] Monitor %s (ID: %d) could not read history for classification: %v", m.Name, m.ID, err)
+		return models.MajorOutage, "Failure rate unavailable (no history)"
+	}
+
+	// Checks com estado desconhecido não entram no cálculo.
+	failures, total := 0, 0
+	worst := models.Operational
+	for _, entry := range entries {
+		if entry.Status == models.Unknown {
+			continue
+		}
+		total++
+		if !entry.responded() {
+			failures++
+			if statusSeverity(entry.Status) > statusSeverity(worst) {
+				worst = entry.Status
+			}
+		}
+	}
+	if total == 0 {
+		return models.MajorOutage, "Failure rate unavailable (no conclusive checks)"
+	}
+	failureRate := failures * 100 / total
+
+	evidence := fmt.Sprintf("Failure rate %d%% over last %d checks", failureRate, total)
+	log.Printf("[HEALTH] Monitor %s (ID: %d) %s", m.Name, m.ID, evidence)
+
+	status := models.Operational
+	switch {
+	case m.MajorOutageThreshold != nil && failureRate >= *m.MajorOutageThreshold:
+		status = models.MajorOutage
+	case m.PartialOutageThreshold != nil && failureRate >= *m.PartialOutageThreshold:
+		status = models.PartialOutage
+	case m.ServiceDegradedThreshold != nil && failureRate >= *m.ServiceDegradedThreshold:
+		status = models.ServiceDegraded
+	}
+
+	// A taxa não escala além do pior status reportado pelos próprios checks da janela:
+	// uma sequência de 429 classificados como service_degraded não vira major_outage.
+	if statusSeverity(status) > statusSeverity(worst) {
+		status = worst
+		evidence += fmt.Sprintf(" (capped at %s, the most severe status in the window)", worst)
+	}
+	return status, evidence
+}
+
+// classifyLatency classifica monitores "no ar, mas lentos": se o check atual e os
+// anteriores da janela de N checks ficaram acima de um limite de latência, retorna
+// partial_outage ou service_degraded. Roda antes de o check ser gravado, para que o
+// histórico e os contadores diários registrem o status rebaixado.
+// O segundo retorno traz as latências observadas como evidência do incidente.
+func classifyLatency(m models.Monitor, latency time.Duration, rdb *redis.Client) (models.Status, string) {
+	if m.LatencyDegradedMs == nil && m.LatencyPartialOutageMs == nil {
+		return models.Operational, ""
+	}
+
+	window := config.AppConfig.LatencyWindow
+	if m.LatencyWindow != nil && *m.LatencyWindow > 0 {
+		window = *m.LatencyWindow
+	}
+
+	var entries []historyEntry
+	if window > 1 {
+		var err error
+		entries, err = recentHistory(m, window-1, rdb)
+		if err != nil {
+			log.Printf("[HEALTH] Monitor %s (ID: %d) could not read history for latency classification: %v", m.Name, m.ID, err)
+			return models.Operational, ""
+		}
+	}
+	entries = append(entries, historyEntry{Status: models.Operational, ResponseTime: latency.Milliseconds()})
+	if len(entries) < window {
+		// Ainda não há checks suficientes para avaliar a janela.
+		return models.Operational, ""
+	}
+
+	// A menor latência da janela indica se todos os checks ficaram acima do limite.
+	latencies := make([]int64, 0, len(entries))
+	fastest := int64(-1)
+	for _, entry := range entries {
+		if !entry.responded() {
+			return models.Operational, ""
+		}
+		latencies = append(latencies, entry.ResponseTime)
+		if fastest == -1 || entry.ResponseTime < fastest {
+			fastest = entry.ResponseTime
+		}
+	}
+
+	status := models.Operational
+	var threshold int
+	switch {
+	case m.LatencyPartialOutageMs != nil && fastest >= int64(*m.LatencyPartialOutageMs):
+		status, threshold = models.PartialOutage, *m.LatencyPartialOutageMs
+	case m.LatencyDegradedMs != nil && fastest >= int64(*m.LatencyDegradedMs):
+		status, threshold = models.ServiceDegraded, *m.LatencyDegradedMs
+	default:
+		return models.Operational, ""
+	}
+
+	evidence := fmt.Sprintf("Response time above %dms in each of the last %d checks (ms: %v)", threshold, len(entries), latencies)
+	log.Printf("[HEALTH] Monitor %s (ID: %d) classified as %s by latency: %s", m.Name, m.ID, status, evidence)
+	return status, evidence
+}
@@ -0,0 +1,250 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"reacher-cron/client"
+	"reacher-cron/config"
+	"reacher-cron/models"
+)
+
+func init() {
+	RegisterChecker(models.MonitorTypeCommand, CheckerFunc(runCommandCheck))
+}
+
+// Limite da saída do plugin guardada como evidência.
+const commandMaxOutput = 4096
+
+// Espera pela saída de subprocessos depois que o plugin termina ou estoura o timeout.
+const commandWaitDelay = time.Second
+
+// Caracteres aceitos no nome das métricas gravadas no Redis.
+var perfLabelSanitizer = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// perfDatum é um item de perfdata no formato 'label'=valor[UOM];warn;crit;min;max.
+type perfDatum struct {
+	Label string   `json:"label"`
+	Value float64  `json:"value"`
+	Unit  string   `json:"unit,omitempty"`
+	Warn  string   `json:"warn,omitempty"`
+	Crit  string   `json:"crit,omitempty"`
+	Min   *float64 `json:"min,omitempty"`
+	Max   *float64 `json:"max,omitempty"`
+}
+
+// runCommandCheck executa um plugin no padrão Nagios/Icinga e converte o exit code:
+// 0 OK => operational, 1 WARNING => service_degraded, 2 CRITICAL => major_outage,
+// 3 UNKNOWN (ou qualquer outro) => unknown. A saída vira evidência e o perfdata, métricas.
+func runCommandCheck(m models.Monitor) CheckResult {
+	path, err := resolvePluginPath(m.Command)
+	if err != nil {
+		return CheckResult{Status: models.Unknown, Reason: reasonPluginUnknown, Error: err.Error()}
+	}
+
+	ctx, cancel := context.WithTimeout(client.Ctx, monitorTimeout(m))
+	defer cancel()
+
+	// Só o stdout segue o formato de plugin (texto | perfdata); o stderr fica como evidência.
+	var output, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, m.CommandArgs...)
+	cmd.Stdout = &output
+	cmd.Stderr = &stderr
+	// Subprocessos do plugin (ex.: sh => curl) podem manter a saída aberta depois que o
+	// processo principal termina ou é morto; WaitDelay limita a espera por eles.
+	cmd.WaitDelay = commandWaitDelay
+
+	startTime := time.Now().UTC()
+	err = cmd.Run()
+	duration := time.Since(startTime)
+	if errors.Is(err, exec.ErrWaitDelay) {
+		// O plugin terminou com sucesso; só a saída de algum subprocesso ficou pendente.
+		err = nil
+	}
+
+	exitCode := 0
+	var exitErr *exec.ExitError
+	// Só é timeout se o plugin foi morto; um plugin que saiu sozinho tem o exit code respeitado.
+	if ctx.Err() == context.DeadlineExceeded && (cmd.ProcessState == nil || !cmd.ProcessState.Exited()) {
+		log.Printf("[HEALTH] Monitor %s (ID: %d) plugin %s timed out", m.Name, m.ID, m.Command)
+		return CheckResult{Status: models.MajorOutage, Latency: duration, Reason: reasonTimeout, Error: "plugin timed out"}
+	} else if errors.As(err, &exitErr) {
+		exitCode = exitErr.ExitCode()
+	} else if err != nil {
+		return CheckResult{Status: models.Unknown, Latency: duration, Reason: reasonPluginUnknown, Error: err.Error()}
+	}
+
+	text, perfdata := parsePluginOutput(output.String())
+	evidence := map[string]interface{}{
+		"exitCode": exitCode,
+		"output":   truncate(output.String(), commandMaxOutput),
+	}
+	if stderr.Len() > 0 {
+		evidence["stderr"] = truncate(stderr.String(), commandMaxOutput)
+	}
+	if len(perfdata) > 0 {
+		evidence["perfdata"] = perfdata
+	}
+
+	result := CheckResult{Latency: duration, Evidence: evidence, Metrics: perfMetrics(perfdata)}
+	switch exitCode {
+	case 0:
+		result.Status = models.Operational
+		return result
+	case 1:
+		result.Status, result.Reason = models.ServiceDegraded, reasonPluginWarning
+	case 2:
+		result.Status, result.Reason = models.MajorOutage, reasonPluginCritical
+	default:
+		result.Status, result.Reason = models.Unknown, reasonPluginUnknown
+	}
+	result.Error = text
+	if result.Error == "" {
+		// Plugin que falhou sem escrever no stdout (ex.: erro do interpretador).
+		result.Error, _, _ = strings.Cut(strings.TrimSpace(stderr.String()), "\n")
+	}
+	log.Printf("[HEALTH] Monitor %s (ID: %d) plugin exited with %d: %s", m.Name, m.ID, exitCode, text)
+	return result
+}
+
+// resolvePluginPath garante que o comando está dentro de CHECK_COMMAND_DIR.
+func resolvePluginPath(command string) (string, error) {
+	dir := config.AppConfig.CheckCommandDir
+	if dir == "" {
+		return "", errors.New("command checks are disabled (CHECK_COMMAND_DIR not set)")
+	}
+	if command == "" {
+		return "", errors.New("no command configured")
+	}
+
+	base, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Clean(filepath.Join(base, command))
+	if filepath.IsAbs(command) {
+		path = filepath.Clean(command)
+	}
+	if rel, err := filepath.Rel(base, path); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("command %q is outside %s", command, base)
+	}
+	return path, nil
+}
+
+// parsePluginOutput separa o texto da primeira linha e o perfdata. O perfdata pode
+// aparecer após '|' na primeira linha e também na saída longa (linhas seguintes).
+func parsePluginOutput(output string) (string, []perfDatum) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	text, perf, _ := strings.Cut(lines[0], "|")
+
+	perfParts := []string{perf}
+	inPerf := false
+	for _, line := range lines[1:] {
+		if !inPerf {
+			if _, after, found := strings.Cut(line, "|"); found {
+				inPerf = true
+				perfParts = append(perfParts, after)
+			}
+			continue
+		}
+		perfParts = append(perfParts, line)
+	}
+
+	var perfdata []perfDatum
+	for _, part := range perfParts {
+		perfdata = append(perfdata, parsePerfdata(part)...)
+	}
+	return strings.TrimSpace(text), perfdata
+}
+
+// parsePerfdata interpreta itens separados por espaço; labels podem estar entre aspas simples.
+func parsePerfdata(raw string) []perfDatum {
+	var items []perfDatum
+
+	rest := strings.TrimSpace(raw)
+	for rest != "" {
+		var label string
+		if strings.HasPrefix(rest, "'") {
+			end := strings.Index(rest[1:], "'=")
+			if end == -1 {
+				break
+			}
+			label = rest[1 : end+1]
+			rest = rest[end+2:]
+		} else {
+			eq := strings.Index(rest, "=")
+			if eq == -1 {
+				break
+			}
+			label = rest[:eq]
+			rest = rest[eq:]
+		}
+		rest = strings.TrimPrefix(rest, "=")
+
+		valueEnd := strings.IndexAny(rest, " \t")
+		if valueEnd == -1 {
+			valueEnd = len(rest)
+		}
+		fields := strings.Split(rest[:valueEnd], ";")
+		rest = strings.TrimSpace(rest[valueEnd:])
+
+		number, unit := splitPerfValue(fields[0])
+		value, err := strconv.ParseFloat(number, 64)
+		if err != nil {
+			continue
+		}
+		item := perfDatum{Label: strings.TrimSpace(label), Value: value, Unit: unit}
+		if len(fields) > 1 {
+			item.Warn = fields[1]
+		}
+		if len(fields) > 2 {
+			item.Crit = fields[2]
+		}
+		if len(fields) > 3 {
+			if v, err := strconv.ParseFloat(fields[3], 64); err == nil {
+				item.Min = &v
+			}
+		}
+		if len(fields) > 4 {
+			if v, err := strconv.ParseFloat(fields[4], 64); err == nil {
+				item.Max = &v
+			}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// splitPerfValue separa o número da unidade (s, ms, %, B, KB, c...).
+func splitPerfValue(v string) (string, string) {
+	i := len(v)
+	for i > 0 && !strings.ContainsRune("0123456789.", rune(v[i-1])) {
+		i--
+	}
+	return v[:i], v[i:]
+}
+
+// perfMetrics converte o perfdata em métricas numéricas com nomes seguros para o Redis.
+func perfMetrics(perfdata []perfDatum) map[string]float64 {
+	if len(perfdata) == 0 {
+		return nil
+	}
+	metrics := make(map[string]float64, len(perfdata))
+	for _, item := range perfdata {
+		name := perfLabelSanitizer.ReplaceAllString(item.Label, "_")
+		if name == "" {
+			continue
+		}
+		metrics["perf_"+name] = item.Value
+	}
+	return metrics
+}
@@ -0,0 +1,169 @@
+package v1
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"reacher-cron/config"
+	"reacher-cron/models"
+)
+
+func TestResolvePluginPath(t *testing.T) {
+	dir := t.TempDir()
+	previous := config.AppConfig
+	config.AppConfig = &config.Config{CheckCommandDir: dir}
+	t.Cleanup(func() { config.AppConfig = previous })
+
+	tests := []struct {
+		name    string
+		command string
+		want    string
+		wantErr bool
+	}{
+		{"plain name", "check_http", filepath.Join(dir, "check_http"), false},
+		{"subdirectory", "net/check_ping", filepath.Join(dir, "net", "check_ping"), false},
+		{"dot segments inside dir", "net/../check_disk", filepath.Join(dir, "check_disk"), false},
+		{"name starting with dots", "..check", filepath.Join(dir, "..check"), false},
+		{"absolute inside dir", filepath.Join(dir, "check_load"), filepath.Join(dir, "check_load"), false},
+		{"parent escape", "../bin/sh", "", true},
+		{"nested escape", "net/../../etc/passwd", "", true},
+		{"escape to parent dir", "..", "", true},
+		{"absolute outside dir", "/bin/sh", "", true},
+		{"absolute sibling prefix", dir + "-other/check", "", true},
+		{"empty command", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolvePluginPath(tt.command)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("resolvePluginPath(%q) = %q, want error", tt.command, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolvePluginPath(%q) unexpected error: %v", tt.command, err)
+			}
+			if got != tt.want {
+				t.Errorf("resolvePluginPath(%q) = %q, want %q", tt.command, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolvePluginPathDisabled(t *testing.T) {
+	previous := config.AppConfig
+	config.AppConfig = &config.Config{}
+	t.Cleanup(func() { config.AppConfig = previous })
+
+	if _, err := resolvePluginPath("check_http"); err == nil {
+		t.Fatal("expected error when CHECK_COMMAND_DIR is not set")
+	}
+}
+
+func TestParsePerfdata(t *testing.T) {
+	float := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name string
+		raw  string
+		want []perfDatum
+	}{
+		{
+			name: "single value with unit",
+			raw:  "time=0.25s",
+			want: []perfDatum{{Label: "time", Value: 0.25, Unit: "s"}},
+		},
+		{
+			name: "thresholds and bounds",
+			raw:  "load1=0.5;1;2;0;10",
+			want: []perfDatum{{Label: "load1", Value: 0.5, Warn: "1", Crit: "2", Min: float(0), Max: float(10)}},
+		},
+		{
+			name: "quoted label with spaces",
+			raw:  "'disk used'=75%;80;90",
+			want: []perfDatum{{Label: "disk used", Value: 75, Unit: "%", Warn: "80", Crit: "90"}},
+		},
+		{
+			name: "quoted label with equals sign",
+			raw:  "'a=b'=1",
+			want: []perfDatum{{Label: "a=b", Value: 1}},
+		},
+		{
+			name: "multiple items",
+			raw:  "rta=1.2ms;100;500 'packet loss'=0%",
+			want: []perfDatum{
+				{Label: "rta", Value: 1.2, Unit: "ms", Warn: "100", Crit: "500"},
+				{Label: "packet loss", Value: 0, Unit: "%"},
+			},
+		},
+		{
+			name: "non-numeric value is skipped",
+			raw:  "state=U rta=3ms",
+			want: []perfDatum{{Label: "rta", Value: 3, Unit: "ms"}},
+		},
+		{
+			name: "unterminated quote",
+			raw:  "'broken=1",
+			want: nil,
+		},
+		{
+			name: "empty",
+			raw:  "  ",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parsePerfdata(tt.raw)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsePerfdata(%q) = %+v, want %+v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePluginOutput(t *testing.T) {
+	output := "DISK OK - free space | '/'=10GB;;;0;100\nlong output line\nmore | '/home'=20GB\n'/var'=5GB\n"
+
+	text, perfdata := parsePluginOutput(output)
+	if text != "DISK OK - free space" {
+		t.Errorf("text = %q, want %q", text, "DISK OK - free space")
+	}
+
+	var labels []string
+	for _, item := range perfdata {
+		labels = append(labels, item.Label)
+	}
+	if want := []string{"/", "/home", "/var"}; !reflect.DeepEqual(labels, want) {
+		t.Errorf("perfdata labels = %v, want %v", labels, want)
+	}
+}
+
+func TestRunCommandCheckSeparatesStderr(t *testing.T) {
+	dir := t.TempDir()
+	previous := config.AppConfig
+	config.AppConfig = &config.Config{CheckCommandDir: dir, HTTPTimeout: 5 * time.Second}
+	t.Cleanup(func() { config.AppConfig = previous })
+
+	script := "#!/bin/sh\necho 'noise=1' >&2\necho 'LOAD WARNING | load=3;2;4'\nexit 1\n"
+	if err := os.WriteFile(filepath.Join(dir, "check_load"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	result := runCommandCheck(models.Monitor{Name: "load", Command: "check_load"})
+	if result.Status != models.ServiceDegraded || result.Error != "LOAD WARNING" {
+		t.Fatalf("status = %s, error = %q", result.Status, result.Error)
+	}
+	if got := result.Evidence["stderr"]; got != "noise=1\n" {
+		t.Errorf("stderr evidence = %q", got)
+	}
+	if _, ok := result.Metrics["perf_load"]; !ok || len(result.Metrics) != 1 {
+		t.Errorf("metrics = %v, want only perfdata from stdout", result.Metrics)
+	}
+}
@@ -0,0 +1,215 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"reacher-cron/client"
+	"reacher-cron/models"
+
+	"github.com/robfig/cron/v3"
+)
+
+func init() {
+	RegisterChecker(models.MonitorTypeCronJob, CheckerFunc(runCronJobCheck))
+}
+
+// jobRunKey guarda o estado da execução atual/última do job (hash Redis).
+func jobRunKey(monitorID int) string {
+	return fmt.Sprintf("monitor:%d:run", monitorID)
+}
+
+// jobRunsKey guarda a lista das execuções concluídas, com duração e exit code.
+func jobRunsKey(monitorID int) string {
+	return fmt.Sprintf("monitor:%d:runs", monitorID)
+}
+
+// jobRun é o estado da execução mais recente de um cron job.
+type jobRun struct {
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+	ExitCode   *int
+}
+
+// RecordJobStart registra o início de uma execução do job dono do token.
+func RecordJobStart(token string) (int, error) {
+	monitorID, err := lookupHeartbeatToken(token)
+	if err != nil {
+		return 0, err
+	}
+
+	rdb := client.ConnectRedis()
+	now := time.Now().UTC().Format(time.RFC3339)
+	pipe := rdb.TxPipeline()
+	pipe.Del(client.Ctx, jobRunKey(monitorID))
+	pipe.HSet(client.Ctx, jobRunKey(monitorID), "startedAt", now)
+	if _, err := pipe.Exec(client.Ctx); err != nil {
+		return 0, err
+	}
+
+	log.Printf("[CRONJOB] Run started for monitor ID %d", monitorID)
+	return monitorID, nil
+}
+
+// RecordJobFinish registra o fim da execução com o exit code (0 = sucesso),
+// grava a duração em monitor:<id>:runs e avalia o monitor imediatamente.
+func RecordJobFinish(token string, exitCode int) (int, error) {
+	monitorID, err := lookupHeartbeatToken(token)
+	if err != nil {
+		return 0, err
+	}
+
+	rdb := client.ConnectRedis()
+	finishedAt := time.Now().UTC()
+
+	run, err := loadJobRun(monitorID)
+	if err != nil {
+		return 0, err
+	}
+
+	err = rdb.HSet(client.Ctx, jobRunKey(monitorID),
+		"finishedAt", finishedAt.Format(time.RFC3339),
+		"exitCode", exitCode,
+	).Err()
+	if err != nil {
+		return 0, err
+	}
+
+	record := map[string]interface{}{
+		"finishedAt": finishedAt.Format(time.RFC3339),
+		"exitCode":   exitCode,
+	}
+	if run.StartedAt != nil && run.FinishedAt == nil {
+		record["startedAt"] = run.StartedAt.Format(time.RFC3339)
+		record["duration"] = finishedAt.Sub(*run.StartedAt).Milliseconds()
+	}
+	recordJSON, _ := json.Marshal(record)
+	if err := rdb.RPush(client.Ctx, jobRunsKey(monitorID), recordJSON).Err(); err != nil {
+		log.Printf("[REDIS] Error registering run for monitor ID %d: %v", monitorID, err)
+	}
+	if err := rdb.LTrim(client.Ctx, jobRunsKey(monitorID), -1000, -1).Err(); err != nil {
+		log.Printf("[REDIS] Error trimming runs list for monitor ID %d: %v", monitorID, err)
+	}
+
+	log.Printf("[CRONJOB] Run finished for monitor ID %d with exit code %d", monitorID, exitCode)
+
+	// Avalia o monitor na hora para que falhas abram (e sucessos resolvam) incidentes sem esperar o próximo tick.
+	// doHealthCheck serializa com o check agendado, então os dois não abrem incidentes em paralelo.
+	go func() {
+		m, err := FetchMonitor(monitorID)
+		if err != nil {
+			log.Printf("[CRONJOB] Error loading monitor ID %d: %v", monitorID, err)
+			return
+		}
+		if m.Status != "Active" {
+			return
+		}
+		doHealthCheck(m, rdb, client.ConnectPostgres())
+	}()
+
+	return monitorID, nil
+}
+
+// loadJobRun lê o estado da execução mais recente do job.
+func loadJobRun(monitorID int) (jobRun, error) {
+	var run jobRun
+
+	data, err := client.ConnectRedis().HGetAll(client.Ctx, jobRunKey(monitorID)).Result()
+	if err != nil {
+		return run, err
+	}
+
+	if t, err := time.Parse(time.RFC3339, data["startedAt"]); err == nil {
+		run.StartedAt = &t
+	}
+	if t, err := time.Parse(time.RFC3339, data["finishedAt"]); err == nil {
+		run.FinishedAt = &t
+	}
+	if code, err := strconv.Atoi(data["exitCode"]); err == nil {
+		run.ExitCode = &code
+	}
+	return run, nil
+}
+
+// runCronJobCheck avalia a execução mais recente do job contra o agendamento (Interval):
+//   - execução concluída com exit code diferente de zero => major_outage (job_failed)
+//   - execução que passou (ou está passando) de MaxDuration => service_degraded (overrun)
+//   - início não recebido até o próximo horário + tolerância => major_outage (late_start)
+func runCronJobCheck(m models.Monitor) CheckResult {
+	schedule, err := cron.ParseStandard(m.Interval)
+	if err != nil {
+		return CheckResult{Status: models.Unknown, Reason: reasonInvalidConfig, Error: fmt.Sprintf("invalid schedule %q: %v", m.Interval, err)}
+	}
+
+	// Sem o estado da execução não há como avaliar o job; não é falha dele.
+	run, err := loadJobRun(m.ID)
+	if err != nil {
+		return CheckResult{Status: models.Unknown, Reason: reasonConnectionError, Error: err.Error()}
+	}
+
+	now := time.Now().UTC()
+	evidence := map[string]interface{}{}
+	var duration time.Duration
+	if run.StartedAt != nil {
+		evidence["startedAt"] = run.StartedAt.Format(time.RFC3339)
+		duration = now.Sub(*run.StartedAt)
+		if run.FinishedAt != nil {
+			evidence["finishedAt"] = run.FinishedAt.Format(time.RFC3339)
+			duration = run.FinishedAt.Sub(*run.StartedAt)
+		}
+	}
+	if run.ExitCode != nil {
+		evidence["exitCode"] = *run.ExitCode
+	}
+
+	// Execução concluída com falha explícita.
+	if run.FinishedAt != nil && run.ExitCode != nil && *run.ExitCode != 0 {
+		msg := fmt.Sprintf("job run failed with exit code %d", *run.ExitCode)
+		return CheckResult{Status: models.MajorOutage, Latency: duration, Reason: reasonJobFailed, Error: msg, Evidence: evidence}
+	}
+
+	running := run.StartedAt != nil && run.FinishedAt == nil
+	overran := run.StartedAt != nil && m.MaxDuration != nil && duration > time.Duration(*m.MaxDuration)*time.Second
+
+	// Execução em andamento além da duração máxima.
+	if running && overran {
+		msg := fmt.Sprintf("job running for %s, longer than max duration %ds", duration.Round(time.Second), *m.MaxDuration)
+		return CheckResult{Status: models.ServiceDegraded, Latency: duration, Reason: reasonOverrun, Error: msg, Evidence: evidence}
+	}
+
+	// Execução em andamento dentro de MaxDuration: o atraso do próximo início só é
+	// avaliado depois que ela terminar. Sem MaxDuration o prazo do próximo início
+	// continua valendo, senão uma execução que nunca termina ficaria operacional.
+	if running && m.MaxDuration != nil {
+		return CheckResult{Status: models.Operational, Latency: duration, Evidence: evidence}
+	}
+
+	// Próximo início esperado após o último início (ou após a criação do monitor).
+	since := m.CreatedAt
+	if run.StartedAt != nil {
+		since = *run.StartedAt
+	}
+	if !since.IsZero() {
+		next := schedule.Next(since)
+		deadline := next.Add(scheduleGrace(m, schedule, next))
+		evidence["nextStartDeadline"] = deadline.UTC().Format(time.RFC3339)
+		if now.After(deadline) {
+			msg := fmt.Sprintf("job did not start by %s", deadline.UTC().Format(time.RFC3339))
+			if running {
+				msg = fmt.Sprintf("job run started at %s still running past next start deadline %s", since.Format(time.RFC3339), deadline.UTC().Format(time.RFC3339))
+			}
+			return CheckResult{Status: models.MajorOutage, Latency: duration, Reason: reasonLateStart, Error: msg, Evidence: evidence}
+		}
+	}
+
+	// Última execução concluída, mas acima da duração máxima.
+	if overran {
+		msg := fmt.Sprintf("job ran for %s, longer than max duration %ds", duration.Round(time.Second), *m.MaxDuration)
+		return CheckResult{Status: models.ServiceDegraded, Latency: duration, Reason: reasonOverrun, Error: msg, Evidence: evidence}
+	}
+
+	return CheckResult{Status: models.Operational, Latency: duration, Evidence: evidence}
+}
@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"database/sql"
+	"testing"
+
+	"reacher-cron/models"
+)
+
+// fakeRow simula uma linha de *sql.Rows com valores já decodificados.
+type fakeRow struct {
+	columns []string
+	values  []interface{}
+}
+
+func (r fakeRow) Columns() ([]string, error) { return r.columns, nil }
+
+func (r fakeRow) Scan(dest ...interface{}) error {
+	for i, d := range dest {
+		switch target := d.(type) {
+		case *sql.NullString:
+			if err := target.Scan(r.values[i]); err != nil {
+				return err
+			}
+		case *sql.RawBytes:
+			*target = sql.RawBytes(nil)
+		}
+	}
+	return nil
+}
+
+func TestScanFirstColumn(t *testing.T) {
+	tests := []struct {
+		name string
+		row  fakeRow
+		want *string
+	}{
+		{"no columns", fakeRow{}, nil},
+		{"single column", fakeRow{[]string{"ok"}, []interface{}{"1"}}, strPtr("1")},
+		{"several columns", fakeRow{[]string{"a", "b"}, []interface{}{int64(42), "x"}}, strPtr("42")},
+		{"null value", fakeRow{[]string{"a"}, []interface{}{nil}}, strPtr("null")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := scanFirstColumn(tt.row)
+			if err != nil {
+				t.Fatalf("scanFirstColumn() unexpected error: %v", err)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("scanFirstColumn() = %v, want %v", deref(got), deref(tt.want))
+			}
+		})
+	}
+}
+
+func TestValidateDBResult(t *testing.T) {
+	one := 1
+	tests := []struct {
+		name     string
+		m        models.Monitor
+		rowCount int
+		value    *string
+		wantErr  bool
+	}{
+		{"no assertions", models.Monitor{}, 0, nil, false},
+		{"rows match", models.Monitor{DBExpectedRows: &one}, 1, strPtr("x"), false},
+		{"rows differ", models.Monitor{DBExpectedRows: &one}, 2, strPtr("x"), true},
+		{"value match", models.Monitor{DBExpectedValue: "ok"}, 1, strPtr("ok"), false},
+		{"value differs", models.Monitor{DBExpectedValue: "ok"}, 1, strPtr("down"), true},
+		{"value without rows", models.Monitor{DBExpectedValue: "ok"}, 0, nil, true},
+		{"value without columns", models.Monitor{DBExpectedValue: "ok"}, 1, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateDBResult(tt.m, tt.rowCount, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateDBResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func strPtr(s string) *string { return &s }
+
+func deref(s *string) string {
+	if s == nil {
+		return "<nil>"
+	}
+	return *s
+}
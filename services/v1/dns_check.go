@@ -0,0 +1,275 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"reacher-cron/client"
+	"reacher-cron/models"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func init() {
+	RegisterChecker(models.MonitorTypeDNS, CheckerFunc(runDNSCheck))
+}
+
+// runDNSCheck consulta o nome do monitor diretamente no nameserver configurado (ou no
+// primeiro do /etc/resolv.conf) e valida os valores esperados e a quantidade de registros.
+// A consulta não passa pelo resolver do sistema, então /etc/hosts e domínios de busca
+// não interferem no resultado.
+func runDNSCheck(m models.Monitor) CheckResult {
+	name := dnsQueryName(m.URL)
+	recordType := m.DNSRecordType
+	if recordType == "" {
+		recordType = "A"
+	}
+
+	details := map[string]interface{}{"recordType": recordType, "query": name}
+	server := systemNameserver()
+	if m.DNSServer != "" {
+		var err error
+		server, err = monitorAddress(m.DNSServer, "53")
+		if err != nil {
+			return CheckResult{Status: models.MajorOutage, Reason: reasonResolutionFailed, Error: err.Error(), Evidence: details}
+		}
+	}
+	details["nameserver"] = server
+
+	ctx, cancel := context.WithTimeout(client.Ctx, monitorTimeout(m))
+	defer cancel()
+
+	startTime := time.Now().UTC()
+	records, err := lookupDNSRecords(ctx, server, recordType, name)
+	duration := time.Since(startTime)
+	details["records"] = records
+
+	if err != nil {
+		reason := reasonResolutionFailed
+		if isTimeout(err) {
+			reason = reasonTimeout
+		}
+		log.Printf("[HEALTH] Monitor %s (ID: %d) DNS %s lookup for %s failed (%s): %v", m.Name, m.ID, recordType, name, reason, err)
+		return CheckResult{Status: models.MajorOutage, Latency: duration, Reason: reason, Error: err.Error(), Evidence: details}
+	}
+
+	if err := validateDNSRecords(m, records); err != nil {
+		log.Printf("[HEALTH] Monitor %s (ID: %d) DNS assertion failed: %v", m.Name, m.ID, err)
+		return CheckResult{Status: models.MajorOutage, Latency: duration, Reason: reasonAssertionFailed, Error: err.Error(), Evidence: details}
+	}
+
+	return CheckResult{Status: models.Operational, Latency: duration, Evidence: details}
+}
+
+// dnsRecordTypes mapeia os tipos suportados para o tipo da consulta.
+var dnsRecordTypes = map[string]dnsmessage.Type{
+	"A":     dnsmessage.TypeA,
+	"AAAA":  dnsmessage.TypeAAAA,
+	"CNAME": dnsmessage.TypeCNAME,
+	"MX":    dnsmessage.TypeMX,
+	"TXT":   dnsmessage.TypeTXT,
+	"NS":    dnsmessage.TypeNS,
+	"SRV":   dnsmessage.TypeSRV,
+}
+
+// lookupDNSRecords consulta o tipo de registro no nameserver e normaliza os valores
+// como strings. Só entram registros do tipo pedido: numa consulta A, por exemplo, os
+// CNAMEs da cadeia ficam de fora. Um CNAME que aponta para o próprio nome consultado
+// não conta como registro.
+func lookupDNSRecords(ctx context.Context, server, recordType, name string) ([]string, error) {
+	qtype, ok := dnsRecordTypes[recordType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported DNS record type %q", recordType)
+	}
+
+	answers, err := exchangeDNS(ctx, server, name, qtype)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []string
+	for _, answer := range answers {
+		switch body := answer.Body.(type) {
+		case *dnsmessage.AResource:
+			records = append(records, net.IP(body.A[:]).String())
+		case *dnsmessage.AAAAResource:
+			records = append(records, net.IP(body.AAAA[:]).String())
+		case *dnsmessage.CNAMEResource:
+			target := normalizeDNSName(body.CNAME.String())
+			if !strings.EqualFold(target, normalizeDNSName(name)) {
+				records = append(records, target)
+			}
+		case *dnsmessage.MXResource:
+			records = append(records, normalizeDNSName(body.MX.String()))
+		case *dnsmessage.TXTResource:
+			records = append(records, strings.Join(body.TXT, ""))
+		case *dnsmessage.NSResource:
+			records = append(records, normalizeDNSName(body.NS.String()))
+		case *dnsmessage.SRVResource:
+			// O nome deve estar no formato completo, ex.: _sip._tcp.example.com
+			records = append(records, net.JoinHostPort(normalizeDNSName(body.Target.String()), strconv.Itoa(int(body.Port))))
+		}
+	}
+	return records, nil
+}
+
+// exchangeDNS envia uma consulta recursiva por UDP, repetindo por TCP quando a resposta
+// vem truncada, e retorna as respostas do tipo pedido. NXDOMAIN e demais RCODEs de erro
+// viram erro; um nome sem registros do tipo retorna uma lista vazia.
+func exchangeDNS(ctx context.Context, server, name string, qtype dnsmessage.Type) ([]dnsmessage.Resource, error) {
+	qname, err := dnsmessage.NewName(normalizeDNSName(name) + ".")
+	if err != nil {
+		return nil, fmt.Errorf("invalid DNS name %q: %v", name, err)
+	}
+	query := dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: uint16(rand.Uint32()), RecursionDesired: true},
+		Questions: []dnsmessage.Question{{Name: qname, Type: qtype, Class: dnsmessage.ClassINET}},
+	}
+	packed, err := query.Pack()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := dnsRoundTrip(ctx, "udp", server, packed, query.ID)
+	if err == nil && response.Truncated {
+		response, err = dnsRoundTrip(ctx, "tcp", server, packed, query.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.RCode {
+	case dnsmessage.RCodeSuccess:
+	case dnsmessage.RCodeNameError:
+		return nil, fmt.Errorf("%s: no such host (NXDOMAIN)", name)
+	default:
+		return nil, fmt.Errorf("%s: nameserver returned %s", name, response.RCode)
+	}
+
+	var answers []dnsmessage.Resource
+	for _, answer := range response.Answers {
+		if answer.Header.Type == qtype {
+			answers = append(answers, answer)
+		}
+	}
+	return answers, nil
+}
+
+// dnsRoundTrip envia a mensagem ao servidor e lê a resposta com o mesmo ID. Por TCP a
+// mensagem vai prefixada com o tamanho (RFC 1035, 4.2.2).
+func dnsRoundTrip(ctx context.Context, network, server string, packed []byte, id uint16) (*dnsmessage.Message, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, network, server)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if network == "tcp" {
+		packed = append([]byte{byte(len(packed) >> 8), byte(len(packed))}, packed...)
+	}
+	if _, err := conn.Write(packed); err != nil {
+		return nil, err
+	}
+
+	for {
+		var buf []byte
+		if network == "tcp" {
+			var size [2]byte
+			if _, err := io.ReadFull(conn, size[:]); err != nil {
+				return nil, err
+			}
+			buf = make([]byte, int(size[0])<<8|int(size[1]))
+			if _, err := io.ReadFull(conn, buf); err != nil {
+				return nil, err
+			}
+		} else {
+			buf = make([]byte, 65535)
+			n, err := conn.Read(buf)
+			if err != nil {
+				return nil, err
+			}
+			buf = buf[:n]
+		}
+
+		var response dnsmessage.Message
+		if err := response.Unpack(buf); err != nil {
+			return nil, fmt.Errorf("invalid DNS response: %v", err)
+		}
+		// Respostas de consultas anteriores (ou forjadas) são descartadas.
+		if response.ID == id && response.Response {
+			return &response, nil
+		}
+	}
+}
+
+// systemNameserver retorna o primeiro nameserver do /etc/resolv.conf ou, sem ele, o
+// servidor local, como faz o resolver da biblioteca padrão.
+func systemNameserver() string {
+	data, err := os.ReadFile("/etc/resolv.conf")
+	if err == nil {
+		for _, line := range strings.Split(string(data), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) >= 2 && fields[0] == "nameserver" {
+				return net.JoinHostPort(fields[1], "53")
+			}
+		}
+	}
+	return net.JoinHostPort("127.0.0.1", "53")
+}
+
+// validateDNSRecords verifica se todos os valores esperados estão presentes
+// e se a quantidade de registros está entre DNSMinRecords e DNSMaxRecords.
+func validateDNSRecords(m models.Monitor, records []string) error {
+	if m.DNSMinRecords != nil && len(records) < *m.DNSMinRecords {
+		return fmt.Errorf("expected at least %d records, got %d", *m.DNSMinRecords, len(records))
+	}
+	if m.DNSMaxRecords != nil && len(records) > *m.DNSMaxRecords {
+		return fmt.Errorf("expected at most %d records, got %d", *m.DNSMaxRecords, len(records))
+	}
+
+	for _, expected := range m.DNSExpectedValues {
+		found := false
+		for _, record := range records {
+			if strings.EqualFold(normalizeDNSName(record), normalizeDNSName(expected)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("expected record %q not found", expected)
+		}
+	}
+
+	if len(records) == 0 {
+		return fmt.Errorf("no records returned")
+	}
+	return nil
+}
+
+// dnsQueryName aceita tanto um nome simples quanto uma URL (dns://example.com).
+func dnsQueryName(target string) string {
+	name := strings.TrimSpace(target)
+	if i := strings.Index(name, "://"); i != -1 {
+		name = name[i+3:]
+	}
+	if i := strings.IndexAny(name, "/?"); i != -1 {
+		name = name[:i]
+	}
+	return name
+}
+
+func normalizeDNSName(name string) string {
+	return strings.TrimSuffix(strings.TrimSpace(name), ".")
+}
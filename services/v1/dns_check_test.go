@@ -0,0 +1,155 @@
+package v1
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"reacher-cron/models"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// serveDNS responde às consultas UDP recebidas em um socket local com as respostas
+// montadas por answer e retorna o endereço do servidor.
+func serveDNS(t *testing.T, answer func(q dnsmessage.Question) (dnsmessage.RCode, []dnsmessage.Resource)) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			var query dnsmessage.Message
+			if err := query.Unpack(buf[:n]); err != nil {
+				continue
+			}
+			rcode, answers := answer(query.Questions[0])
+			response := dnsmessage.Message{
+				Header:    dnsmessage.Header{ID: query.ID, Response: true, RCode: rcode},
+				Questions: query.Questions,
+				Answers:   answers,
+			}
+			packed, err := response.Pack()
+			if err != nil {
+				continue
+			}
+			conn.WriteTo(packed, addr)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func dnsResource(name string, body dnsmessage.ResourceBody) dnsmessage.Resource {
+	return dnsmessage.Resource{
+		Header: dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName(name), Class: dnsmessage.ClassINET, TTL: 60},
+		Body:   body,
+	}
+}
+
+func TestLookupDNSRecords(t *testing.T) {
+	server := serveDNS(t, func(q dnsmessage.Question) (dnsmessage.RCode, []dnsmessage.Resource) {
+		switch q.Name.String() {
+		case "www.example.com.":
+			// Consulta A passando por um CNAME: só os registros A contam.
+			return dnsmessage.RCodeSuccess, []dnsmessage.Resource{
+				dnsResource("www.example.com.", &dnsmessage.CNAMEResource{CNAME: dnsmessage.MustNewName("web.example.com.")}),
+				dnsResource("web.example.com.", &dnsmessage.AResource{A: [4]byte{192, 0, 2, 1}}),
+				dnsResource("web.example.com.", &dnsmessage.AResource{A: [4]byte{192, 0, 2, 2}}),
+			}
+		case "alias.example.com.":
+			return dnsmessage.RCodeSuccess, []dnsmessage.Resource{
+				dnsResource("alias.example.com.", &dnsmessage.CNAMEResource{CNAME: dnsmessage.MustNewName("target.example.com.")}),
+			}
+		case "self.example.com.":
+			return dnsmessage.RCodeSuccess, []dnsmessage.Resource{
+				dnsResource("self.example.com.", &dnsmessage.CNAMEResource{CNAME: dnsmessage.MustNewName("self.example.com.")}),
+			}
+		case "example.com.":
+			return dnsmessage.RCodeSuccess, []dnsmessage.Resource{
+				dnsResource("example.com.", &dnsmessage.MXResource{Pref: 10, MX: dnsmessage.MustNewName("mail.example.com.")}),
+			}
+		case "_sip._tcp.example.com.":
+			return dnsmessage.RCodeSuccess, []dnsmessage.Resource{
+				dnsResource("_sip._tcp.example.com.", &dnsmessage.SRVResource{Port: 5060, Target: dnsmessage.MustNewName("sip.example.com.")}),
+			}
+		}
+		return dnsmessage.RCodeNameError, nil
+	})
+
+	tests := []struct {
+		name       string
+		recordType string
+		query      string
+		want       []string
+		wantErr    bool
+	}{
+		{"A through CNAME chain", "A", "www.example.com", []string{"192.0.2.1", "192.0.2.2"}, false},
+		{"explicit CNAME", "CNAME", "alias.example.com", []string{"target.example.com"}, false},
+		{"CNAME to itself is no record", "CNAME", "self.example.com", nil, false},
+		{"no records of the type", "AAAA", "alias.example.com", nil, false},
+		{"MX", "MX", "example.com.", []string{"mail.example.com"}, false},
+		{"SRV", "SRV", "_sip._tcp.example.com", []string{"sip.example.com:5060"}, false},
+		{"NXDOMAIN", "A", "missing.example.com", nil, true},
+		{"unsupported type", "PTR", "example.com", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+
+			got, err := lookupDNSRecords(ctx, server, tt.recordType, tt.query)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("lookupDNSRecords(%s %s) = %v, want error", tt.recordType, tt.query, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("lookupDNSRecords(%s %s) unexpected error: %v", tt.recordType, tt.query, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("lookupDNSRecords(%s %s) = %v, want %v", tt.recordType, tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateDNSRecords(t *testing.T) {
+	one, two := 1, 2
+	records := []string{"192.0.2.1", "192.0.2.2"}
+
+	tests := []struct {
+		name    string
+		m       models.Monitor
+		records []string
+		wantErr bool
+	}{
+		{"any records", models.Monitor{}, records, false},
+		{"no records", models.Monitor{}, nil, true},
+		{"expected value present", models.Monitor{DNSExpectedValues: []string{"192.0.2.2"}}, records, false},
+		{"expected value missing", models.Monitor{DNSExpectedValues: []string{"192.0.2.3"}}, records, true},
+		{"below minimum", models.Monitor{DNSMinRecords: &two}, records[:1], true},
+		{"above maximum", models.Monitor{DNSMaxRecords: &one}, records, true},
+		{"exact count", models.Monitor{DNSMinRecords: &two, DNSMaxRecords: &two}, records, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateDNSRecords(tt.m, tt.records)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateDNSRecords() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package v1
+
+import (
+	"context"
+	"crypto/tls"
+	"log"
+	"strings"
+	"time"
+
+	"reacher-cron/client"
+	"reacher-cron/models"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func init() {
+	RegisterChecker(models.MonitorTypeGRPC, CheckerFunc(runGRPCCheck))
+}
+
+// runGRPCCheck chama grpc.health.v1.Health/Check no alvo do monitor e converte
+// o status retornado: SERVING => operational, UNKNOWN => service_degraded,
+// NOT_SERVING/SERVICE_UNKNOWN => major_outage.
+func runGRPCCheck(m models.Monitor) CheckResult {
+	useTLS := strings.HasPrefix(m.URL, "grpcs://")
+	if m.GRPCTLS != nil {
+		useTLS = *m.GRPCTLS
+	}
+
+	// Sem TLS não há porta padrão: gRPC em texto puro roda em portas arbitrárias.
+	defaultPort := ""
+	if useTLS {
+		defaultPort = "443"
+	}
+	address, err := monitorAddress(m.URL, defaultPort)
+	if err != nil {
+		return CheckResult{Status: models.Unknown, Reason: reasonInvalidConfig, Error: err.Error()}
+	}
+
+	creds := insecure.NewCredentials()
+	if useTLS {
+		creds = credentials.NewTLS(&tls.Config{})
+	}
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return CheckResult{Status: models.MajorOutage, Reason: reasonConnectionError, Error: err.Error()}
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(client.Ctx, monitorTimeout(m))
+	defer cancel()
+	if len(m.GRPCMetadata) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.New(m.GRPCMetadata))
+	}
+
+	evidence := map[string]interface{}{"service": m.GRPCService, "tls": useTLS}
+
+	startTime := time.Now().UTC()
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: m.GRPCService})
+	duration := time.Since(startTime)
+
+	if err != nil {
+		st := status.Convert(err)
+		evidence["code"] = st.Code().String()
+
+		reason := reasonConnectionError
+		switch st.Code() {
+		case codes.DeadlineExceeded:
+			reason = reasonTimeout
+		case codes.NotFound:
+			// O servidor não conhece o serviço consultado.
+			reason = reasonNotServing
+		}
+		log.Printf("[HEALTH] Monitor %s (ID: %d) gRPC health check failed (%s): %v", m.Name, m.ID, reason, err)
+		return CheckResult{Status: models.MajorOutage, Latency: duration, Reason: reason, Error: st.Message(), Evidence: evidence}
+	}
+
+	servingStatus := resp.GetStatus()
+	evidence["servingStatus"] = servingStatus.String()
+
+	switch servingStatus {
+	case healthpb.HealthCheckResponse_SERVING:
+		return CheckResult{Status: models.Operational, Latency: duration, Evidence: evidence}
+	case healthpb.HealthCheckResponse_UNKNOWN:
+		return CheckResult{Status: models.ServiceDegraded, Latency: duration, Reason: reasonNotServing, Error: "serving status UNKNOWN", Evidence: evidence}
+	default:
+		return CheckResult{Status: models.MajorOutage, Latency: duration, Reason: reasonNotServing, Error: "serving status " + servingStatus.String(), Evidence: evidence}
+	}
+}
@@ -0,0 +1,155 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"reacher-cron/client"
+	"reacher-cron/models"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/robfig/cron/v3"
+)
+
+func init() {
+	RegisterChecker(models.MonitorTypeHeartbeat, CheckerFunc(runHeartbeatCheck))
+}
+
+// heartbeatTokensKey é o hash Redis token => ID do monitor.
+const heartbeatTokensKey = "heartbeat:tokens"
+
+// Tolerância máxima usada quando o monitor não define GracePeriod.
+const defaultScheduleGrace = time.Minute
+
+// ErrUnknownHeartbeatToken indica que nenhum monitor usa o token recebido.
+var ErrUnknownHeartbeatToken = errors.New("unknown heartbeat token")
+
+// heartbeatKey guarda o horário (RFC3339) do último ping recebido pelo monitor.
+func heartbeatKey(monitorID int) string {
+	return fmt.Sprintf("monitor:%d:heartbeat", monitorID)
+}
+
+// RecordHeartbeat registra um ping para o monitor dono do token e retorna o ID do monitor.
+func RecordHeartbeat(token string) (int, error) {
+	monitorID, err := lookupHeartbeatToken(token)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if err := client.ConnectRedis().Set(client.Ctx, heartbeatKey(monitorID), now, 0).Err(); err != nil {
+		return 0, err
+	}
+
+	log.Printf("[HEARTBEAT] Ping received for monitor ID %d", monitorID)
+	return monitorID, nil
+}
+
+// lookupHeartbeatToken retorna o ID do monitor dono do token.
+func lookupHeartbeatToken(token string) (int, error) {
+	idStr, err := client.ConnectRedis().HGet(client.Ctx, heartbeatTokensKey, token).Result()
+	if err == redis.Nil {
+		return 0, ErrUnknownHeartbeatToken
+	} else if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(idStr)
+}
+
+// syncHeartbeatTokens mantém o índice de tokens alinhado com os monitores heartbeat e cronjob.
+func syncHeartbeatTokens(monitors []models.Monitor, rdb *redis.Client) {
+	tokens := make(map[string]interface{})
+	for _, m := range monitors {
+		if (m.Type == models.MonitorTypeHeartbeat || m.Type == models.MonitorTypeCronJob) && m.HeartbeatToken != "" {
+			tokens[m.HeartbeatToken] = m.ID
+		}
+	}
+
+	existing, err := rdb.HKeys(client.Ctx, heartbeatTokensKey).Result()
+	if err != nil {
+		log.Printf("[REDIS] Error reading heartbeat tokens: %v", err)
+		return
+	}
+
+	pipe := rdb.Pipeline()
+	for _, token := range existing {
+		if _, ok := tokens[token]; !ok {
+			pipe.HDel(client.Ctx, heartbeatTokensKey, token)
+		}
+	}
+	if len(tokens) > 0 {
+		pipe.HSet(client.Ctx, heartbeatTokensKey, tokens)
+	}
+	if _, err := pipe.Exec(client.Ctx); err != nil {
+		log.Printf("[REDIS] Error syncing heartbeat tokens: %v", err)
+	}
+}
+
+// runHeartbeatCheck verifica se o último ping chegou a tempo: o ping seguinte ao último
+// recebido deve chegar até o próximo horário do Interval do monitor mais a tolerância.
+func runHeartbeatCheck(m models.Monitor) CheckResult {
+	schedule, err := cron.ParseStandard(m.Interval)
+	if err != nil {
+		return CheckResult{Status: models.Unknown, Reason: reasonInvalidConfig, Error: fmt.Sprintf("invalid interval %q: %v", m.Interval, err)}
+	}
+
+	// Sem o último ping não há como saber se ele atrasou; não é falha do job.
+	lastPing, err := lastHeartbeat(m)
+	if err != nil {
+		return CheckResult{Status: models.Unknown, Reason: reasonConnectionError, Error: err.Error()}
+	}
+
+	evidence := map[string]interface{}{}
+	since := m.CreatedAt
+	if lastPing != nil {
+		since = *lastPing
+		evidence["lastPing"] = lastPing.Format(time.RFC3339)
+	}
+	if since.IsZero() {
+		// Monitor sem pings e sem data de criação: aguarda o primeiro ping.
+		return CheckResult{Status: models.Operational, Evidence: evidence}
+	}
+
+	now := time.Now().UTC()
+	next := schedule.Next(since)
+	deadline := next.Add(scheduleGrace(m, schedule, next))
+	evidence["deadline"] = deadline.UTC().Format(time.RFC3339)
+	evidence["secondsSinceLastPing"] = int64(now.Sub(since).Seconds())
+
+	if now.After(deadline) {
+		msg := fmt.Sprintf("no heartbeat received since %s (expected by %s)", since.UTC().Format(time.RFC3339), deadline.UTC().Format(time.RFC3339))
+		log.Printf("[HEARTBEAT] Monitor %s (ID: %d) %s", m.Name, m.ID, msg)
+		return CheckResult{Status: models.MajorOutage, Reason: reasonMissedHeartbeat, Error: msg, Evidence: evidence}
+	}
+
+	return CheckResult{Status: models.Operational, Evidence: evidence}
+}
+
+// scheduleGrace retorna o GracePeriod do monitor ou, sem ele, metade do intervalo do
+// agendamento limitada a um minuto. Sem tolerância o check, agendado no mesmo Interval
+// do job, avaliaria o prazo no exato horário em que o ping ainda está a caminho.
+func scheduleGrace(m models.Monitor, schedule cron.Schedule, next time.Time) time.Duration {
+	if m.GracePeriod != nil {
+		return time.Duration(*m.GracePeriod) * time.Second
+	}
+	return min(schedule.Next(next).Sub(next)/2, defaultScheduleGrace)
+}
+
+// lastHeartbeat retorna o horário do último ping, ou nil se nunca houve ping.
+func lastHeartbeat(m models.Monitor) (*time.Time, error) {
+	v, err := client.ConnectRedis().Get(client.Ctx, heartbeatKey(m.ID)).Result()
+	if err == redis.Nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,159 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptrace"
+	"strings"
+	"time"
+
+	"reacher-cron/client"
+	"reacher-cron/config"
+	"reacher-cron/models"
+)
+
+func init() {
+	RegisterChecker(models.MonitorTypeHTTP, CheckerFunc(runHTTPCheck))
+}
+
+// runHTTPCheck executa a requisição HTTP do monitor usando o cliente compartilhado,
+// respeitando o timeout configurado no monitor.
+func runHTTPCheck(m models.Monitor) CheckResult {
+	ctx, cancel := context.WithTimeout(client.Ctx, monitorTimeout(m))
+	defer cancel()
+
+	// Mede as fases da requisição (DNS, conexão, TLS, primeiro byte e corpo).
+	tracer := &httpPhaseTracer{}
+	ctx = httptrace.WithClientTrace(ctx, tracer.clientTrace())
+
+	req, err := buildHTTPRequest(ctx, m)
+	if err != nil {
+		log.Printf("[HEALTH] Monitor %s (ID: %d) invalid request: %v", m.Name, m.ID, err)
+		return CheckResult{Status: models.Unknown, Reason: reasonInvalidConfig, Error: err.Error()}
+	}
+
+	startTime := time.Now().UTC()
+	tracer.start = startTime
+	resp, err := client.GetHTTPClient().Do(req)
+	duration := time.Since(startTime)
+
+	if err != nil {
+		reason := reasonConnectionError
+		if isTimeout(err) {
+			reason = reasonTimeout
+		} else if isCertificateError(err) {
+			reason = reasonCertInvalid
+		}
+		log.Printf("[HEALTH] Monitor %s (ID: %d) check failed (%s): %v", m.Name, m.ID, reason, err)
+		return CheckResult{Status: models.MajorOutage, Latency: duration, Reason: reason, Error: err.Error(), Timings: tracer.timings()}
+	}
+	defer resp.Body.Close()
+
+	// Lê o corpo até o limite configurado; isso também permite reutilizar a conexão.
+	// Um byte a mais indica que o corpo foi cortado.
+	limit := config.AppConfig.HTTPMaxBodyBytes
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	truncated := int64(len(body)) > limit
+	if truncated {
+		body = body[:limit]
+	}
+	tracer.bodyDone = time.Now()
+	if err != nil {
+		reason := reasonConnectionError
+		if isTimeout(err) {
+			reason = reasonTimeout
+		}
+		log.Printf("[HEALTH] Monitor %s (ID: %d) failed reading body (%s): %v", m.Name, m.ID, reason, err)
+		return CheckResult{Status: models.MajorOutage, Latency: duration, Reason: reason, Error: err.Error(), Timings: tracer.timings()}
+	}
+
+	result := evaluateHTTPResponse(m, resp, body, duration)
+	result.Timings = tracer.timings()
+	if truncated {
+		// As asserções viram só o início do corpo: um bodyNotContains pode ter passado
+		// porque o termo estava depois do corte.
+		if result.Evidence == nil {
+			result.Evidence = map[string]interface{}{}
+		}
+		result.Evidence["truncated"] = true
+		log.Printf("[HEALTH] Monitor %s (ID: %d) response body truncated at %d bytes", m.Name, m.ID, limit)
+	}
+	return result
+}
+
+// evaluateHTTPResponse aplica as regras do monitor (status esperado, asserções
+// e certificado) sobre a resposta já lida.
+func evaluateHTTPResponse(m models.Monitor, resp *http.Response, body []byte, duration time.Duration) CheckResult {
+	if !m.ExpectedStatus.Matches(resp.StatusCode) {
+		return CheckResult{
+			Status:  classifyStatusCode(m, resp.StatusCode),
+			Latency: duration,
+			Reason:  reasonUnexpectedStatus,
+			Error:   fmt.Sprintf("unexpected status code %d", resp.StatusCode),
+		}
+	}
+
+	if err := evaluateBodyAssertions(m, body); err != nil {
+		log.Printf("[HEALTH] Monitor %s (ID: %d) body assertion failed: %v", m.Name, m.ID, err)
+		return CheckResult{Status: models.MajorOutage, Latency: duration, Reason: reasonAssertionFailed, Error: err.Error()}
+	}
+
+	result := CheckResult{Status: models.Operational, Latency: duration}
+	if len(m.JSONAssertions) > 0 {
+		assertionResults, err := evaluateJSONAssertions(m.JSONAssertions, body)
+		result.Evidence = map[string]interface{}{"assertions": assertionResults}
+		if err != nil {
+			log.Printf("[HEALTH] Monitor %s (ID: %d) JSON assertion failed: %v", m.Name, m.ID, err)
+			result.Status = models.MajorOutage
+			result.Reason = reasonAssertionFailed
+			result.Error = err.Error()
+		}
+	}
+
+	// Em HTTPS, avalia também a validade do certificado apresentado.
+	if resp.TLS != nil && result.Status == models.Operational {
+		applyCertificateStatus(m, &result, *resp.TLS)
+	}
+
+	return result
+}
+
+// buildHTTPRequest monta a requisição com método, cabeçalhos e corpo do monitor.
+func buildHTTPRequest(ctx context.Context, m models.Monitor) (*http.Request, error) {
+	method := m.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	var body io.Reader
+	if m.Body != nil && *m.Body != "" {
+		body = strings.NewReader(*m.Body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, m.URL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if body != nil {
+		switch m.BodyType {
+		case "json":
+			req.Header.Set("Content-Type", "application/json")
+		case "form":
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+	}
+	// Cabeçalhos explícitos do monitor prevalecem sobre os padrões.
+	for key, value := range m.Headers {
+		if strings.EqualFold(key, "Host") {
+			req.Host = value
+			continue
+		}
+		req.Header.Set(key, value)
+	}
+
+	return req, nil
+}
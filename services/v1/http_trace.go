@@ -0,0 +1,62 @@
+package v1
+
+import (
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// httpPhaseTracer registra os instantes de cada fase de uma requisição HTTP.
+// Fases não executadas (ex.: conexão reutilizada) ficam de fora do resultado.
+type httpPhaseTracer struct {
+	mu sync.Mutex
+
+	start                 time.Time
+	dnsStart, dnsDone     time.Time
+	connectStart, connect time.Time
+	tlsStart, tlsDone     time.Time
+	firstByte             time.Time
+	bodyDone              time.Time
+}
+
+func (t *httpPhaseTracer) clientTrace() *httptrace.ClientTrace {
+	mark := func(field *time.Time) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		// Mantém apenas a primeira marcação (happy eyeballs pode discar mais de uma vez).
+		if field.IsZero() {
+			*field = time.Now()
+		}
+	}
+
+	return &httptrace.ClientTrace{
+		DNSStart:             func(httptrace.DNSStartInfo) { mark(&t.dnsStart) },
+		DNSDone:              func(httptrace.DNSDoneInfo) { mark(&t.dnsDone) },
+		ConnectStart:         func(string, string) { mark(&t.connectStart) },
+		ConnectDone:          func(string, string, error) { mark(&t.connect) },
+		TLSHandshakeStart:    func() { mark(&t.tlsStart) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { mark(&t.tlsDone) },
+		GotFirstResponseByte: func() { mark(&t.firstByte) },
+	}
+}
+
+// timings retorna a duração de cada fase em milissegundos.
+func (t *httpPhaseTracer) timings() map[string]int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	result := map[string]int64{}
+	phase := func(name string, from, to time.Time) {
+		if !from.IsZero() && !to.IsZero() {
+			result[name] = to.Sub(from).Milliseconds()
+		}
+	}
+
+	phase("dns", t.dnsStart, t.dnsDone)
+	phase("connect", t.connectStart, t.connect)
+	phase("tls", t.tlsStart, t.tlsDone)
+	phase("ttfb", t.start, t.firstByte)
+	phase("transfer", t.firstByte, t.bodyDone)
+	return result
+}
@@ -13,7 +13,9 @@ import (
 	"github.com/go-redis/redis/v8"
 )
 
-func ProcessIncidentCreation(m models.Monitor, healthStatus models.Status, db *sql.DB, rdb *redis.Client) {
+// ProcessIncidentCreation abre um incidente para o monitor, se a automação estiver habilitada.
+// evidence (opcional) descreve o que motivou o incidente e é anexado à descrição.
+func ProcessIncidentCreation(m models.Monitor, healthStatus models.Status, evidence string, db *sql.DB, rdb *redis.Client) {
 	if m.AutoIncident == nil || !*m.AutoIncident {
 		return
 	}
@@ -61,6 +63,9 @@ func ProcessIncidentCreation(m models.Monitor, healthStatus models.Status, db *s
 	`
 	title := "Incident for monitor: " + m.Name
 	description := "Automatic incident creation triggered by health check at " + time.Now().Format(time.RFC3339)
+	if evidence != "" {
+		description += "\n\n" + evidence
+	}
 	notifySubscribers := false
 
 	var incidentID int
@@ -101,7 +106,7 @@ func ProcessIncidentCreation(m models.Monitor, healthStatus models.Status, db *s
 	}
 
 	// 4) Sincroniza o incidente no Redis
-	if err := syncIncidentToRedis(incidentID, m, healthStatus, createdAt, updatedAt, rdb); err != nil {
+	if err := syncIncidentToRedis(incidentID, m, healthStatus, description, createdAt, updatedAt, rdb); err != nil {
 		log.Printf("[INCIDENT] Failed to sync incident (ID: %d) to Redis: %v", incidentID, err)
 	}
 }
@@ -154,7 +159,7 @@ func ProcessIncidentAutoResolve(m models.Monitor, db *sql.DB, rdb *redis.Client)
 	}
 }
 
-func syncIncidentToRedis(incidentID int, m models.Monitor, status models.Status,
+func syncIncidentToRedis(incidentID int, m models.Monitor, status models.Status, description string,
 	createdAt, updatedAt time.Time, rdb *redis.Client) error {
 
 	key := "incident:" + strconv.Itoa(incidentID)
@@ -167,7 +172,7 @@ func syncIncidentToRedis(incidentID int, m models.Monitor, status models.Status,
 		"createdAt":         createdAt.Format(time.RFC3339),
 		"updatedAt":         updatedAt.Format(time.RFC3339),
 		"title":             "Incident for monitor: " + m.Name,
-		"description":       "Automatic incident creation triggered by health check",
+		"description":       description,
 		"notifySubscribers": false,
 	}
 
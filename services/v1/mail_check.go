@@ -0,0 +1,597 @@
+package v1
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/smtp"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"reacher-cron/client"
+	"reacher-cron/models"
+)
+
+func init() {
+	RegisterChecker(models.MonitorTypeSMTP, CheckerFunc(runSMTPCheck))
+	RegisterChecker(models.MonitorTypeIMAP, CheckerFunc(runIMAPCheck))
+	RegisterChecker(models.MonitorTypePOP3, CheckerFunc(runPOP3Check))
+}
+
+// Valores padrão do modo round-trip.
+const (
+	defaultRoundTripDeadline = 120 * time.Second
+	roundTripPollInterval    = 5 * time.Second
+)
+
+// Tamanho máximo aceito para um literal {n} enviado pelo servidor IMAP.
+const imapMaxLiteral = 1 << 20
+
+// mailStages mede a duração de cada etapa do diálogo com o servidor de e-mail.
+type mailStages struct {
+	timings map[string]int64
+	last    time.Time
+	current string
+}
+
+func newMailStages() *mailStages {
+	return &mailStages{timings: map[string]int64{}, last: time.Now()}
+}
+
+// begin marca o início de uma etapa; usado para identificar onde uma falha ocorreu.
+func (s *mailStages) begin(name string) {
+	s.current = name
+	s.last = time.Now()
+}
+
+// done registra a duração da etapa atual.
+func (s *mailStages) done() {
+	s.timings[s.current] = time.Since(s.last).Milliseconds()
+}
+
+// runSMTPCheck conecta no servidor SMTP, faz EHLO, STARTTLS (quando disponível ou exigido)
+// e AUTH opcional. Com MailRoundTrip configurado, envia uma mensagem marcada e
+// verifica a chegada na caixa IMAP dentro do prazo.
+func runSMTPCheck(m models.Monitor) CheckResult {
+	address, host, implicitTLS, err := mailTarget(m.URL, "25", "465", "smtps")
+	if err != nil {
+		return CheckResult{Status: models.MajorOutage, Reason: reasonConnectionError, Error: err.Error()}
+	}
+
+	startTime := time.Now().UTC()
+	stages := newMailStages()
+	deadline := startTime.Add(monitorTimeout(m))
+
+	stages.begin("connect")
+	conn, err := dialMail(address, host, implicitTLS, deadline)
+	if err != nil {
+		return mailFailure(m, startTime, stages, reasonConnectionError, err)
+	}
+	defer conn.Close()
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return mailFailure(m, startTime, stages, reasonUnexpectedResponse, err)
+	}
+	defer c.Close()
+	stages.done()
+
+	stages.begin("ehlo")
+	if err := c.Hello("reacher-cron"); err != nil {
+		return mailFailure(m, startTime, stages, reasonUnexpectedResponse, err)
+	}
+	stages.done()
+
+	if !implicitTLS {
+		hasStartTLS, _ := c.Extension("STARTTLS")
+		if m.MailStartTLS != nil && *m.MailStartTLS && !hasStartTLS {
+			stages.begin("starttls")
+			return mailFailure(m, startTime, stages, reasonUnexpectedResponse, errors.New("server does not offer STARTTLS"))
+		}
+		if hasStartTLS && (m.MailStartTLS == nil || *m.MailStartTLS) {
+			stages.begin("starttls")
+			if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+				return mailFailure(m, startTime, stages, mailTLSReason(err), err)
+			}
+			stages.done()
+		}
+	}
+
+	if m.MailUsername != "" {
+		stages.begin("auth")
+		if err := c.Auth(smtp.PlainAuth("", m.MailUsername, m.MailPassword, host)); err != nil {
+			return mailFailure(m, startTime, stages, reasonAuthFailed, err)
+		}
+		stages.done()
+	}
+
+	if m.MailRoundTrip != nil {
+		if result, failed := runMailRoundTrip(m, c, conn, startTime, stages); failed {
+			return result
+		}
+	}
+
+	c.Quit()
+	return CheckResult{Status: models.Operational, Latency: time.Since(startTime), Timings: stages.timings}
+}
+
+// runMailRoundTrip envia a mensagem de teste pela sessão SMTP já autenticada e espera
+// a chegada no IMAP. Retorna failed=true com o resultado da falha.
+func runMailRoundTrip(m models.Monitor, c *smtp.Client, conn net.Conn, startTime time.Time, stages *mailStages) (CheckResult, bool) {
+	rt := m.MailRoundTrip
+	token := fmt.Sprintf("%d-%d", m.ID, time.Now().UnixNano())
+
+	stages.begin("send")
+	message := strings.Join([]string{
+		"From: " + rt.From,
+		"To: " + rt.To,
+		"Subject: reacher-cron probe " + token,
+		"Date: " + time.Now().UTC().Format(time.RFC1123Z),
+		"Message-ID: <" + token + "@reacher-cron>",
+		"X-Reacher-Probe: " + token,
+		"",
+		"Round-trip delivery probe for monitor " + m.Name + ".",
+		"",
+	}, "\r\n")
+
+	err := c.Mail(rt.From)
+	if err == nil {
+		err = c.Rcpt(rt.To)
+	}
+	var w io.WriteCloser
+	if err == nil {
+		w, err = c.Data()
+	}
+	if err == nil {
+		_, err = io.WriteString(w, message)
+	}
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil {
+		return mailFailure(m, startTime, stages, reasonDeliveryFailed, err), true
+	}
+	stages.done()
+	c.Quit()
+
+	// O prazo de entrega nunca passa do timeout do monitor: o check inteiro tem que
+	// terminar antes do próximo tick. Para prazos longos, aumente também o timeout.
+	deadlineDuration := defaultRoundTripDeadline
+	if rt.Deadline > 0 {
+		deadlineDuration = time.Duration(rt.Deadline) * time.Second
+	}
+	deadline := time.Now().Add(deadlineDuration)
+	if checkDeadline := startTime.Add(monitorTimeout(m)); checkDeadline.Before(deadline) {
+		deadline = checkDeadline
+		deadlineDuration = time.Until(deadline).Round(time.Second)
+	}
+
+	stages.begin("delivery")
+	session, err := openIMAPSession(rt.IMAPURL, rt.Username, rt.Password, nil, deadline, nil)
+	if err != nil {
+		return mailFailure(m, startTime, stages, reasonDeliveryFailed, err), true
+	}
+	defer session.logout()
+
+	mailbox := rt.Mailbox
+	if mailbox == "" {
+		mailbox = "INBOX"
+	}
+	if _, err := session.command("SELECT %s", imapQuote(mailbox)); err != nil {
+		return mailFailure(m, startTime, stages, reasonDeliveryFailed, err), true
+	}
+
+	for {
+		ids, err := session.search("HEADER X-Reacher-Probe " + imapQuote(token))
+		if err != nil {
+			return mailFailure(m, startTime, stages, reasonDeliveryFailed, err), true
+		}
+		if len(ids) > 0 {
+			// Remove a mensagem de teste para não acumular na caixa; uma falha aqui não
+			// invalida a entrega, mas as mensagens vão se acumulando.
+			if _, err := session.command("STORE %s +FLAGS.SILENT (\\Deleted)", strings.Join(ids, ",")); err != nil {
+				log.Printf("[HEALTH] Monitor %s (ID: %d) could not flag probe message %s for deletion: %v", m.Name, m.ID, token, err)
+			} else if _, err := session.command("EXPUNGE"); err != nil {
+				log.Printf("[HEALTH] Monitor %s (ID: %d) could not expunge probe message %s: %v", m.Name, m.ID, token, err)
+			}
+			break
+		}
+		if time.Now().Add(roundTripPollInterval).After(deadline) {
+			err := fmt.Errorf("probe message %s not delivered within %s", token, deadlineDuration)
+			return mailFailure(m, startTime, stages, reasonDeliveryFailed, err), true
+		}
+		time.Sleep(roundTripPollInterval)
+		if _, err := session.command("NOOP"); err != nil {
+			return mailFailure(m, startTime, stages, reasonDeliveryFailed, err), true
+		}
+	}
+	stages.done()
+
+	return CheckResult{}, false
+}
+
+// runIMAPCheck conecta no servidor IMAP, aplica STARTTLS quando disponível ou exigido,
+// faz LOGIN (se houver credenciais) e abre a INBOX em modo somente leitura.
+func runIMAPCheck(m models.Monitor) CheckResult {
+	startTime := time.Now().UTC()
+	stages := newMailStages()
+
+	session, err := openIMAPSession(m.URL, m.MailUsername, m.MailPassword, m.MailStartTLS, startTime.Add(monitorTimeout(m)), stages)
+	if err != nil {
+		reason := reasonConnectionError
+		var authErr *mailAuthError
+		if errors.As(err, &authErr) {
+			reason = reasonAuthFailed
+		} else if stages.current != "connect" {
+			reason = mailTLSReason(err)
+		}
+		return mailFailure(m, startTime, stages, reason, err)
+	}
+	defer session.logout()
+
+	if m.MailUsername != "" {
+		stages.begin("select")
+		if _, err := session.command("EXAMINE INBOX"); err != nil {
+			return mailFailure(m, startTime, stages, reasonUnexpectedResponse, err)
+		}
+		stages.done()
+	}
+
+	return CheckResult{Status: models.Operational, Latency: time.Since(startTime), Timings: stages.timings}
+}
+
+// runPOP3Check conecta no servidor POP3, aplica STLS quando disponível ou exigido,
+// autentica com USER/PASS (se houver credenciais) e consulta STAT.
+func runPOP3Check(m models.Monitor) CheckResult {
+	address, host, implicitTLS, err := mailTarget(m.URL, "110", "995", "pop3s")
+	if err != nil {
+		return CheckResult{Status: models.MajorOutage, Reason: reasonConnectionError, Error: err.Error()}
+	}
+
+	startTime := time.Now().UTC()
+	stages := newMailStages()
+	deadline := startTime.Add(monitorTimeout(m))
+
+	stages.begin("connect")
+	conn, err := dialMail(address, host, implicitTLS, deadline)
+	if err != nil {
+		return mailFailure(m, startTime, stages, reasonConnectionError, err)
+	}
+	defer func() { conn.Close() }()
+
+	r := bufio.NewReader(conn)
+	if _, err := pop3Response(r); err != nil {
+		return mailFailure(m, startTime, stages, reasonUnexpectedResponse, err)
+	}
+	stages.done()
+
+	cmd := func(line string) (string, error) {
+		if _, err := fmt.Fprintf(conn, "%s\r\n", line); err != nil {
+			return "", err
+		}
+		return pop3Response(r)
+	}
+
+	if !implicitTLS && (m.MailStartTLS == nil || *m.MailStartTLS) {
+		hasSTLS := false
+		if _, err := cmd("CAPA"); err == nil {
+			capabilities, _ := readDotTerminated(r)
+			for _, capability := range capabilities {
+				if strings.EqualFold(strings.TrimSpace(capability), "STLS") {
+					hasSTLS = true
+				}
+			}
+		}
+
+		if hasSTLS || (m.MailStartTLS != nil && *m.MailStartTLS) {
+			stages.begin("starttls")
+			if !hasSTLS {
+				return mailFailure(m, startTime, stages, reasonUnexpectedResponse, errors.New("server does not offer STLS"))
+			}
+			if _, err := cmd("STLS"); err != nil {
+				return mailFailure(m, startTime, stages, reasonUnexpectedResponse, err)
+			}
+			tlsConn := tls.Client(conn, &tls.Config{ServerName: host})
+			if err := tlsConn.Handshake(); err != nil {
+				return mailFailure(m, startTime, stages, mailTLSReason(err), err)
+			}
+			conn = tlsConn
+			r = bufio.NewReader(conn)
+			stages.done()
+		}
+	}
+
+	if m.MailUsername != "" {
+		stages.begin("auth")
+		if _, err := cmd("USER " + m.MailUsername); err != nil {
+			return mailFailure(m, startTime, stages, reasonAuthFailed, err)
+		}
+		if _, err := cmd("PASS " + m.MailPassword); err != nil {
+			return mailFailure(m, startTime, stages, reasonAuthFailed, err)
+		}
+		stages.done()
+
+		stages.begin("stat")
+		if _, err := cmd("STAT"); err != nil {
+			return mailFailure(m, startTime, stages, reasonUnexpectedResponse, err)
+		}
+		stages.done()
+	}
+
+	cmd("QUIT")
+	return CheckResult{Status: models.Operational, Latency: time.Since(startTime), Timings: stages.timings}
+}
+
+func mailFailure(m models.Monitor, startTime time.Time, stages *mailStages, reason string, err error) CheckResult {
+	if isTimeout(err) {
+		reason = reasonTimeout
+	}
+	log.Printf("[HEALTH] Monitor %s (ID: %d) %s check failed at %s (%s): %v", m.Name, m.ID, m.Type, stages.current, reason, err)
+	return CheckResult{
+		Status:   models.MajorOutage,
+		Latency:  time.Since(startTime),
+		Reason:   reason,
+		Error:    fmt.Sprintf("%s: %v", stages.current, err),
+		Timings:  stages.timings,
+		Evidence: map[string]interface{}{"stage": stages.current},
+	}
+}
+
+func mailTLSReason(err error) string {
+	if isCertificateError(err) {
+		return reasonCertInvalid
+	}
+	return reasonConnectionError
+}
+
+// mailTarget extrai endereço e host da URL do monitor. O esquema terminado em "s"
+// (smtps, imaps, pop3s) indica TLS implícito e muda a porta padrão.
+func mailTarget(rawURL, plainPort, tlsPort, tlsScheme string) (string, string, bool, error) {
+	implicitTLS := false
+	target := rawURL
+	if strings.Contains(rawURL, "://") {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return "", "", false, fmt.Errorf("invalid target %q: %v", rawURL, err)
+		}
+		implicitTLS = u.Scheme == tlsScheme
+		target = u.Host
+	}
+
+	port := plainPort
+	if implicitTLS {
+		port = tlsPort
+	}
+	address, err := monitorAddress(target, port)
+	if err != nil {
+		return "", "", false, err
+	}
+	host, _, _ := net.SplitHostPort(address)
+	return address, host, implicitTLS, nil
+}
+
+// dialMail abre a conexão (com TLS implícito quando indicado) e aplica o deadline.
+func dialMail(address, host string, implicitTLS bool, deadline time.Time) (net.Conn, error) {
+	ctx, cancel := context.WithDeadline(client.Ctx, deadline)
+	defer cancel()
+
+	var conn net.Conn
+	var err error
+	if implicitTLS {
+		dialer := &tls.Dialer{Config: &tls.Config{ServerName: host}}
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	} else {
+		var dialer net.Dialer
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return nil, err
+	}
+	conn.SetDeadline(deadline)
+	return conn, nil
+}
+
+// pop3Response lê uma linha de status POP3 e retorna erro em "-ERR".
+func pop3Response(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if !strings.HasPrefix(line, "+OK") {
+		return "", fmt.Errorf("server replied %q", line)
+	}
+	return line, nil
+}
+
+// readDotTerminated lê uma resposta POP3 multilinha até a linha ".".
+func readDotTerminated(r *bufio.Reader) ([]string, error) {
+	var lines []string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return lines, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "." {
+			return lines, nil
+		}
+		lines = append(lines, strings.TrimPrefix(line, "."))
+	}
+}
+
+// mailAuthError indica credenciais recusadas pelo servidor.
+type mailAuthError struct{ err error }
+
+func (e *mailAuthError) Error() string { return e.err.Error() }
+func (e *mailAuthError) Unwrap() error { return e.err }
+
+// imapSession é um cliente IMAP mínimo, suficiente para login, seleção e busca.
+type imapSession struct {
+	conn net.Conn
+	r    *bufio.Reader
+	tag  int
+}
+
+// openIMAPSession conecta, aplica STARTTLS (quando disponível ou exigido) e faz LOGIN.
+// stages é opcional; quando informado, registra a duração de cada etapa.
+func openIMAPSession(rawURL, username, password string, startTLS *bool, deadline time.Time, stages *mailStages) (*imapSession, error) {
+	if stages == nil {
+		stages = newMailStages()
+	}
+
+	address, host, implicitTLS, err := mailTarget(rawURL, "143", "993", "imaps")
+	if err != nil {
+		return nil, err
+	}
+
+	stages.begin("connect")
+	conn, err := dialMail(address, host, implicitTLS, deadline)
+	if err != nil {
+		return nil, err
+	}
+	s := &imapSession{conn: conn, r: bufio.NewReader(conn)}
+
+	greeting, err := s.readLine()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if !strings.HasPrefix(greeting, "* OK") && !strings.HasPrefix(greeting, "* PREAUTH") {
+		conn.Close()
+		return nil, fmt.Errorf("unexpected greeting %q", greeting)
+	}
+	stages.done()
+
+	if !implicitTLS && (startTLS == nil || *startTLS) {
+		capabilities, err := s.command("CAPABILITY")
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		hasStartTLS := strings.Contains(strings.ToUpper(strings.Join(capabilities, " ")), "STARTTLS")
+
+		if hasStartTLS || (startTLS != nil && *startTLS) {
+			stages.begin("starttls")
+			if !hasStartTLS {
+				conn.Close()
+				return nil, errors.New("server does not offer STARTTLS")
+			}
+			if _, err := s.command("STARTTLS"); err != nil {
+				conn.Close()
+				return nil, err
+			}
+			tlsConn := tls.Client(conn, &tls.Config{ServerName: host})
+			if err := tlsConn.Handshake(); err != nil {
+				conn.Close()
+				return nil, err
+			}
+			s.conn = tlsConn
+			s.r = bufio.NewReader(tlsConn)
+			stages.done()
+		}
+	}
+
+	if username != "" {
+		stages.begin("login")
+		if _, err := s.command("LOGIN %s %s", imapQuote(username), imapQuote(password)); err != nil {
+			s.conn.Close()
+			return nil, &mailAuthError{err}
+		}
+		stages.done()
+	}
+
+	return s, nil
+}
+
+// command envia um comando com tag e retorna as linhas não marcadas da resposta.
+func (s *imapSession) command(format string, args ...interface{}) ([]string, error) {
+	s.tag++
+	tag := "a" + strconv.Itoa(s.tag)
+	if _, err := fmt.Fprintf(s.conn, "%s %s\r\n", tag, fmt.Sprintf(format, args...)); err != nil {
+		return nil, err
+	}
+
+	var untagged []string
+	for {
+		line, err := s.readLine()
+		if err != nil {
+			return untagged, err
+		}
+		if !strings.HasPrefix(line, tag+" ") {
+			untagged = append(untagged, line)
+			continue
+		}
+
+		status := strings.TrimPrefix(line, tag+" ")
+		if !strings.HasPrefix(status, "OK") {
+			return untagged, fmt.Errorf("server replied %q", status)
+		}
+		return untagged, nil
+	}
+}
+
+// search executa SEARCH e retorna os números das mensagens encontradas.
+func (s *imapSession) search(criteria string) ([]string, error) {
+	lines, err := s.command("SEARCH %s", criteria)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, line := range lines {
+		if strings.HasPrefix(line, "* SEARCH") {
+			ids = append(ids, strings.Fields(strings.TrimPrefix(line, "* SEARCH"))...)
+		}
+	}
+	return ids, nil
+}
+
+func (s *imapSession) logout() {
+	s.command("LOGOUT")
+	s.conn.Close()
+}
+
+// readLine lê uma linha da resposta, incorporando literais {n} quando presentes.
+func (s *imapSession) readLine() (string, error) {
+	line, err := s.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	line = strings.TrimRight(line, "\r\n")
+
+	for strings.HasSuffix(line, "}") {
+		open := strings.LastIndex(line, "{")
+		if open == -1 {
+			break
+		}
+		size, err := strconv.Atoi(line[open+1 : len(line)-1])
+		if err != nil {
+			break
+		}
+		if size < 0 || size > imapMaxLiteral {
+			return "", fmt.Errorf("invalid IMAP literal size %d", size)
+		}
+		literal := make([]byte, size)
+		if _, err := io.ReadFull(s.r, literal); err != nil {
+			return "", err
+		}
+		rest, err := s.r.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		line = line[:open] + string(literal) + strings.TrimRight(rest, "\r\n")
+	}
+	return line, nil
+}
+
+// imapQuote coloca o valor entre aspas, escapando \ e ".
+func imapQuote(v string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(v) + `"`
+}
@@ -1,74 +1,88 @@
 package v1
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
-	"net/http"
+	"net"
+	"sync"
 	"time"
 
 	"reacher-cron/client"
+	"reacher-cron/config"
 	"reacher-cron/models"
 
 	"github.com/go-redis/redis/v8"
 )
 
+// Limite da espera entre tentativas.
+const maxRetryBackoff = 30 * time.Second
+
+// monitorCheckLocks guarda um *sync.Mutex por ID de monitor.
+var monitorCheckLocks sync.Map
+
+// lockMonitorCheck serializa os checks de um mesmo monitor (agendados ou disparados por
+// eventos, como o fim de um cron job) e retorna a função que libera o lock.
+func lockMonitorCheck(monitorID int) func() {
+	lock, _ := monitorCheckLocks.LoadOrStore(monitorID, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	return lock.(*sync.Mutex).Unlock
+}
+
 // doHealthCheck executa o health check para um monitor,
 // usando as regras e, em seguida, chamando ProcessIncidentCreation se necessário.
 func doHealthCheck(m models.Monitor, rdb *redis.Client, db *sql.DB) {
-	startTime := time.Now().UTC()
-	resp, err := http.Get(m.URL)
-	duration := time.Since(startTime)
+	// Checks concorrentes do mesmo monitor poderiam abrir incidentes duplicados.
+	defer lockMonitorCheck(m.ID)()
 
-	var healthStatus models.Status
-	if err != nil {
-		log.Printf("[HEALTH] Monitor %s (ID: %d) check failed: %v", m.Name, m.ID, err)
-		healthStatus = models.MajorOutage
-	} else {
-		defer resp.Body.Close()
-		if m.ExpectedStatus != nil && resp.StatusCode == *m.ExpectedStatus {
-			healthStatus = models.Operational
-		} else {
-			healthStatus = models.MajorOutage
+	result := runCheckWithRetries(m)
+
+	// Monitor respondeu, mas pode estar lento demais nos últimos checks. A classificação
+	// vem antes do registro para que o histórico guarde o status rebaixado.
+	if result.Status == models.Operational {
+		if status, latencyEvidence := classifyLatency(m, result.Latency, rdb); status != models.Operational {
+			result.Status, result.Reason, result.Error = status, reasonSlowResponse, latencyEvidence
 		}
 	}
 
+	healthStatus := result.Status
+	var evidence string
+
 	// Registra o estado e atualiza métricas no Redis.
-	registerStateHistoryAndMetrics(m, healthStatus, duration, rdb)
+	registerStateHistoryAndMetrics(m, result, rdb)
+
+	switch {
+	case healthStatus == models.Unknown:
+		// Estado indeterminado: fica no histórico, mas não abre nem resolve incidentes.
+	case healthStatus == models.Operational:
+		// Respondeu dentro dos limites de latência.
+	case result.Reason == reasonSlowResponse:
+		evidence = result.Error
+	default:
+		evidence = fmt.Sprintf("Check failed (%s): %s", result.Reason, result.Error)
 
-	// entra no looping de validação somente se o status for diferente de operacional
-	if healthStatus != models.Operational {
 		// Verifica se a classificação detalhada está habilitada e a automação de incidentes também.
 		if m.ThresholdClassification != nil && *m.ThresholdClassification && m.AutoIncident != nil && *m.AutoIncident {
-
-			// evaluateDetailedStatus usa thresholds para classificar o monitor como
-			// service_degraded, partial_outage ou major_outage.
-
-			timeout := 5 * time.Second
-			if m.Timeout != nil {
-				timeout = time.Duration(*m.Timeout) * time.Millisecond
-			}
-
-			failureRate := int((duration.Seconds() / timeout.Seconds()) * 100)
-			if m.ServiceDegradedThreshold != nil && m.PartialOutageThreshold != nil &&
-				failureRate >= *m.ServiceDegradedThreshold && failureRate < *m.PartialOutageThreshold {
-				healthStatus = models.ServiceDegraded
-			}
-			if m.PartialOutageThreshold != nil && m.MajorOutageThreshold != nil &&
-				failureRate >= *m.PartialOutageThreshold && failureRate < *m.MajorOutageThreshold {
-				healthStatus = models.PartialOutage
-			}
-			if m.MajorOutageThreshold != nil && failureRate >= *m.MajorOutageThreshold {
-				healthStatus = models.MajorOutage
-			}
-
-			healthStatus = models.MajorOutage
+			// classifyFailureRate usa a taxa de falhas da janela recente para classificar o monitor
+			// como service_degraded, partial_outage ou major_outage.
+			var rateEvidence string
+			healthStatus, rateEvidence = classifyFailureRate(m, rdb)
+			evidence += "\n" + rateEvidence
 		}
+	}
+
+	switch {
+	case healthStatus == models.Unknown:
+		log.Printf("[HEALTH] Monitor %s (ID: %d) returned unknown state (%s), skipping incident evaluation", m.Name, m.ID, result.Error)
+	case healthStatus != models.Operational:
 		// Processa a criação/atualização do incidente com base no status final avaliado.
-		ProcessIncidentCreation(m, healthStatus, db, rdb)
-	} else {
-		// check no redis se temos incidnet abertos se tiver e o status for up devemo encerrar o incident
+		ProcessIncidentCreation(m, healthStatus, evidence, db, rdb)
+	case result.Status != models.Operational:
+		log.Printf("[HEALTH] Monitor %s (ID: %d) check failed but failure rate is below thresholds", m.Name, m.ID)
+	default:
 		// Se o monitor está operacional, verifica se o auto fechamento está habilitado.
 		if m.AutoResolveIncident != nil && *m.AutoResolveIncident {
 			ProcessIncidentAutoResolve(m, db, rdb)
@@ -79,12 +93,101 @@ func doHealthCheck(m models.Monitor, rdb *redis.Client, db *sql.DB) {
 	log.Printf("[HEALTH] Monitor %s (ID: %d) check completed with status %s", m.Name, m.ID, healthStatus)
 }
 
+// runCheckWithRetries repete o check com backoff exponencial enquanto houver falha
+// transitória, até esgotar as tentativas configuradas. Só a última tentativa é reportada.
+func runCheckWithRetries(m models.Monitor) CheckResult {
+	retries := config.AppConfig.CheckRetries
+	if m.Retries != nil {
+		retries = *m.Retries
+	}
+	backoff := config.AppConfig.CheckRetryBackoff
+	if m.RetryBackoff != nil {
+		backoff = time.Duration(*m.RetryBackoff) * time.Millisecond
+	}
+
+	var result CheckResult
+	for attempt := 1; ; attempt++ {
+		result = runCheck(m)
+		result.Attempts = attempt
+		if !isTransientFailure(result) || attempt > retries {
+			return result
+		}
+
+		log.Printf("[HEALTH] Monitor %s (ID: %d) attempt %d/%d failed (%s), retrying in %s", m.Name, m.ID, attempt, retries+1, result.Reason, backoff)
+		time.Sleep(backoff)
+		backoff = min(backoff*2, maxRetryBackoff)
+	}
+}
+
+// isTransientFailure indica falhas de rede que podem passar numa nova tentativa.
+// Resultados determinísticos (certificado expirando, regras de status, plugin em
+// WARNING, heartbeat perdido, estado desconhecido...) não são repetidos.
+func isTransientFailure(result CheckResult) bool {
+	if result.Status != models.MajorOutage {
+		return false
+	}
+	return result.Reason == reasonTimeout || result.Reason == reasonConnectionError
+}
+
+// monitorTimeout retorna o timeout do monitor ou o padrão global.
+func monitorTimeout(m models.Monitor) time.Duration {
+	if m.Timeout != nil && *m.Timeout > 0 {
+		return time.Duration(*m.Timeout) * time.Millisecond
+	}
+	return config.AppConfig.HTTPTimeout
+}
+
+// statusSeverity ordena os status do menos para o mais grave.
+func statusSeverity(status models.Status) int {
+	switch status {
+	case models.Operational:
+		return 0
+	case models.ServiceDegraded:
+		return 1
+	case models.PartialOutage:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// isTimeout indica se o erro foi causado por estouro de tempo.
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// monitorHistoryKey retorna a lista Redis com o histórico de checks do monitor.
+func monitorHistoryKey(monitorID int) string {
+	return fmt.Sprintf("monitor:%d:history", monitorID)
+}
+
 // registerStateHistoryAndMetrics registra o histórico e incrementa contadores de status.
-func registerStateHistoryAndMetrics(m models.Monitor, healthStatus models.Status, duration time.Duration, rdb *redis.Client) {
+func registerStateHistoryAndMetrics(m models.Monitor, result CheckResult, rdb *redis.Client) {
+	healthStatus := result.Status
 	stateHistory := map[string]interface{}{
 		"timestamp":    time.Now().UTC().Format(time.RFC3339),
 		"status":       healthStatus,
-		"responseTime": duration.Milliseconds(),
+		"responseTime": result.Latency.Milliseconds(),
+		"attempts":     result.Attempts,
+	}
+	if result.Reason != "" {
+		stateHistory["reason"] = result.Reason
+		stateHistory["error"] = result.Error
+	}
+	if len(result.Timings) > 0 {
+		stateHistory["timings"] = result.Timings
+	}
+	if len(result.Metrics) > 0 {
+		stateHistory["metrics"] = result.Metrics
+	}
+	for key, value := range result.Evidence {
+		if _, exists := stateHistory[key]; !exists {
+			stateHistory[key] = value
+		}
 	}
 
 	stateJSON, err := json.Marshal(stateHistory)
@@ -93,7 +196,7 @@ func registerStateHistoryAndMetrics(m models.Monitor, healthStatus models.Status
 		return
 	}
 
-	historyKey := fmt.Sprintf("monitor:%d:history", m.ID)
+	historyKey := monitorHistoryKey(m.ID)
 	// Armazena o registro no final da lista de histórico:
 	err = rdb.RPush(client.Ctx, historyKey, stateJSON).Err()
 	if err != nil {
@@ -119,4 +222,29 @@ func registerStateHistoryAndMetrics(m models.Monitor, healthStatus models.Status
 	if err := rdb.HIncrBy(client.Ctx, metricsKey, string(healthStatus), 1).Err(); err != nil {
 		log.Printf("[REDIS] Error incrementing counter for status %s for monitor %s (ID: %d): %v", healthStatus, m.Name, m.ID, err)
 	}
+
+	// Acumula a duração de cada fase; a média é timing_<fase>_ms / timing_<fase>_samples.
+	// A contagem é por fase porque checks que falham no meio não medem as fases seguintes.
+	if len(result.Timings) > 0 {
+		pipe := rdb.Pipeline()
+		for phase, ms := range result.Timings {
+			pipe.HIncrBy(client.Ctx, metricsKey, "timing_"+phase+"_ms", ms)
+			pipe.HIncrBy(client.Ctx, metricsKey, "timing_"+phase+"_samples", 1)
+		}
+		if _, err := pipe.Exec(client.Ctx); err != nil {
+			log.Printf("[REDIS] Error incrementing timing metrics for monitor %s (ID: %d): %v", m.Name, m.ID, err)
+		}
+	}
+
+	// Acumula as métricas do check; a média é metric_<nome>_sum / metric_<nome>_samples.
+	if len(result.Metrics) > 0 {
+		pipe := rdb.Pipeline()
+		for name, value := range result.Metrics {
+			pipe.HIncrByFloat(client.Ctx, metricsKey, "metric_"+name+"_sum", value)
+			pipe.HIncrBy(client.Ctx, metricsKey, "metric_"+name+"_samples", 1)
+		}
+		if _, err := pipe.Exec(client.Ctx); err != nil {
+			log.Printf("[REDIS] Error incrementing check metrics for monitor %s (ID: %d): %v", m.Name, m.ID, err)
+		}
+	}
 }
@@ -2,7 +2,10 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"log"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -66,6 +69,21 @@ func FetchAllMonitors() ([]models.Monitor, error) {
 	return monitors, nil
 }
 
+// FetchMonitor busca um único monitor pelo ID no Redis.
+func FetchMonitor(id int) (models.Monitor, error) {
+	ctx := client.Ctx
+	rdb := client.ConnectRedis()
+
+	data, err := rdb.HGetAll(ctx, fmt.Sprintf("monitor:%d", id)).Result()
+	if err != nil {
+		return models.Monitor{}, err
+	}
+	if len(data) == 0 {
+		return models.Monitor{}, fmt.Errorf("monitor %d not found", id)
+	}
+	return mapToMonitor(data, ctx, rdb)
+}
+
 // mapToMonitor converte o hash Redis em models.Monitor.
 // Mantém praticamente o mesmo código de antes.
 func mapToMonitor(data map[string]string, ctx context.Context, rdb *redis.Client) (models.Monitor, error) {
@@ -81,6 +99,143 @@ func mapToMonitor(data map[string]string, ctx context.Context, rdb *redis.Client
 	m.Status = data["status"]
 	m.Interval = data["interval"]
 
+	// invalidField registra um campo malformado: o monitor é marcado como mal configurado
+	// em vez de rodar sem a configuração pedida (ex.: sem as asserções).
+	invalidField := func(key string, err error) {
+		log.Printf("[MONITOR] Invalid %s for monitor %d: %v", key, id, err)
+		m.ConfigErrors = append(m.ConfigErrors, fmt.Sprintf("invalid %s: %v", key, err))
+	}
+
+	m.Type = strings.ToLower(data["type"])
+	if m.Type == "" {
+		m.Type = models.MonitorTypeHTTP
+	}
+	m.Payload = data["payload"]
+	m.ExpectedResponse = data["expectedResponse"]
+	m.PayloadEncoding = strings.ToLower(data["payloadEncoding"])
+	m.ExpectedResponseRegex = data["expectedResponseRegex"]
+	if m.ExpectedResponseRegex != "" {
+		if re, err := regexp.Compile(m.ExpectedResponseRegex); err == nil {
+			m.ExpectedResponsePattern = re
+		} else {
+			invalidField("expectedResponseRegex", err)
+		}
+	}
+
+	// DNS
+	m.DNSRecordType = strings.ToUpper(data["dnsRecordType"])
+	m.DNSServer = data["dnsServer"]
+	m.DNSExpectedValues = parseStringList(data["dnsExpectedValues"])
+
+	// Heartbeat
+	m.HeartbeatToken = data["heartbeatToken"]
+
+	// Transação: passos armazenados como array JSON.
+	if v, ok := data["steps"]; ok && v != "" {
+		var steps []models.TransactionStep
+		if err := json.Unmarshal([]byte(v), &steps); err == nil {
+			m.Steps = steps
+		} else {
+			invalidField("steps", err)
+		}
+	}
+
+	// Bancos de dados
+	m.DBQuery = data["dbQuery"]
+	m.DBExpectedValue = data["dbExpectedValue"]
+
+	// Comando externo; commandArgs é um array JSON.
+	m.Command = data["command"]
+	if v, ok := data["commandArgs"]; ok && v != "" {
+		var args []string
+		if err := json.Unmarshal([]byte(v), &args); err == nil {
+			m.CommandArgs = args
+		} else {
+			invalidField("commandArgs", err)
+		}
+	}
+
+	// E-mail; mailRoundTrip é um objeto JSON.
+	m.MailUsername = data["mailUsername"]
+	m.MailPassword = data["mailPassword"]
+	if v, ok := data["mailStartTls"]; ok && v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			m.MailStartTLS = &b
+		}
+	}
+	if v, ok := data["mailRoundTrip"]; ok && v != "" {
+		var roundTrip models.MailRoundTrip
+		if err := json.Unmarshal([]byte(v), &roundTrip); err == nil {
+			m.MailRoundTrip = &roundTrip
+		} else {
+			invalidField("mailRoundTrip", err)
+		}
+	}
+
+	// SSH
+	m.SSHBanner = data["sshBanner"]
+	m.SSHHostKey = data["sshHostKey"]
+	m.SSHUsername = data["sshUsername"]
+	m.SSHPrivateKey = data["sshPrivateKey"]
+	m.SSHCommand = data["sshCommand"]
+	m.SSHExpectedOutput = data["sshExpectedOutput"]
+
+	// Brokers
+	m.BrokerTopic = data["brokerTopic"]
+
+	// gRPC
+	m.GRPCService = data["grpcService"]
+	if v, ok := data["grpcTls"]; ok && v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			m.GRPCTLS = &b
+		}
+	}
+	if v, ok := data["grpcMetadata"]; ok && v != "" {
+		var metadata map[string]string
+		if err := json.Unmarshal([]byte(v), &metadata); err == nil {
+			m.GRPCMetadata = metadata
+		} else {
+			invalidField("grpcMetadata", err)
+		}
+	}
+
+	// Requisição HTTP: método, cabeçalhos (JSON) e corpo.
+	m.Method = strings.ToUpper(data["method"])
+	if v, ok := data["headers"]; ok && v != "" {
+		var headers map[string]string
+		if err := json.Unmarshal([]byte(v), &headers); err == nil {
+			m.Headers = headers
+		} else {
+			invalidField("headers", err)
+		}
+	}
+	if v, ok := data["body"]; ok && v != "" {
+		m.Body = &v
+	}
+	m.BodyType = strings.ToLower(data["bodyType"])
+
+	// Asserções do corpo da resposta.
+	m.BodyContains = parseStringList(data["bodyContains"])
+	m.BodyNotContains = parseStringList(data["bodyNotContains"])
+	m.BodyRegex = data["bodyRegex"]
+	if m.BodyRegex != "" {
+		if re, err := regexp.Compile(m.BodyRegex); err == nil {
+			m.BodyPattern = re
+		} else {
+			invalidField("bodyRegex", err)
+		}
+	}
+
+	// Asserções JSON path, armazenadas como array JSON.
+	if v, ok := data["jsonAssertions"]; ok && v != "" {
+		var assertions []models.JSONAssertion
+		if err := json.Unmarshal([]byte(v), &assertions); err == nil {
+			m.JSONAssertions = assertions
+		} else {
+			invalidField("jsonAssertions", err)
+		}
+	}
+
 	// Converte LastChecked, se disponível.
 	if v, ok := data["lastChecked"]; ok && v != "" {
 		if t, err := time.Parse(time.RFC3339, v); err == nil {
@@ -102,12 +257,46 @@ func mapToMonitor(data map[string]string, ctx context.Context, rdb *redis.Client
 		return nil
 	}
 
-	m.ExpectedStatus = convertInt("expectedStatus")
+	// expectedStatus aceita "200", "2xx", "200-299" ou "200,201,204"; padrão 2xx.
+	m.ExpectedStatus = defaultExpectedStatus
+	if v, ok := data["expectedStatus"]; ok && v != "" {
+		if spec, err := parseStatusCodeSpec(v); err == nil {
+			m.ExpectedStatus = spec
+		} else {
+			log.Printf("[MONITOR] Invalid expectedStatus for monitor %d, using 2xx: %v", id, err)
+		}
+	}
+	// statusCodeRules: objeto JSON {"429":"service_degraded","5xx":"major_outage"}.
+	if v, ok := data["statusCodeRules"]; ok && v != "" {
+		if rules, err := parseStatusCodeRules(v); err == nil {
+			m.StatusCodeRules = rules
+		} else {
+			invalidField("statusCodeRules", err)
+		}
+	}
+
 	m.Timeout = convertInt("timeout")
 	m.ServiceDegradedThreshold = convertInt("serviceDegradedThreshold")
 	m.PartialOutageThreshold = convertInt("partialOutageThreshold")
 	m.MajorOutageThreshold = convertInt("majorOutageThreshold")
 	m.EscalationWindow = convertInt("escalationWindow")
+	m.FailureWindow = convertInt("failureWindow")
+	m.LatencyDegradedMs = convertInt("latencyDegradedMs")
+	m.LatencyPartialOutageMs = convertInt("latencyPartialOutageMs")
+	m.LatencyWindow = convertInt("latencyWindow")
+	m.GracePeriod = convertInt("gracePeriod")
+	m.MaxDuration = convertInt("maxDuration")
+	m.DBExpectedRows = convertInt("dbExpectedRows")
+	m.Retries = convertInt("retries")
+	m.RetryBackoff = convertInt("retryBackoff")
+	m.DNSMinRecords = convertInt("dnsMinRecords")
+	m.DNSMaxRecords = convertInt("dnsMaxRecords")
+	if m.DNSMinRecords != nil && m.DNSMaxRecords != nil && *m.DNSMinRecords > *m.DNSMaxRecords {
+		invalidField("dnsMaxRecords", fmt.Errorf("%d is lower than dnsMinRecords %d", *m.DNSMaxRecords, *m.DNSMinRecords))
+	}
+	m.CertDegradedDays = convertInt("certDegradedDays")
+	m.CertPartialOutageDays = convertInt("certPartialOutageDays")
+	m.CertMajorOutageDays = convertInt("certMajorOutageDays")
 
 	// Converte autoIncident para booleano.
 	if v, ok := data["autoIncident"]; ok && v != "" {
@@ -165,3 +354,17 @@ func mapToMonitor(data map[string]string, ctx context.Context, rdb *redis.Client
 
 	return m, nil
 }
+
+// parseStringList aceita um array JSON (["a","b"]) ou um valor simples.
+func parseStringList(v string) []string {
+	if v == "" {
+		return nil
+	}
+	var list []string
+	if strings.HasPrefix(strings.TrimSpace(v), "[") {
+		if err := json.Unmarshal([]byte(v), &list); err == nil {
+			return list
+		}
+	}
+	return []string{v}
+}
@@ -44,6 +44,9 @@ func syncMonitorJobs() {
 		return
 	}
 
+	// Atualiza o índice token => monitor usado pelo endpoint de heartbeat
+	syncHeartbeatTokens(monitors, rdb)
+
 	mu.Lock()
 	defer mu.Unlock()
 
@@ -0,0 +1,226 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"time"
+
+	"reacher-cron/client"
+	"reacher-cron/models"
+
+	"github.com/go-redis/redis/v8"
+	"golang.org/x/crypto/ssh"
+)
+
+func init() {
+	RegisterChecker(models.MonitorTypeSSH, CheckerFunc(runSSHCheck))
+}
+
+// errHostKeyCaptured interrompe o handshake depois de verificar a chave do host
+// quando o monitor não tem credenciais para autenticar.
+var errHostKeyCaptured = errors.New("host key captured")
+
+// hostKeyChangedError indica que o servidor apresentou uma chave diferente da conhecida.
+type hostKeyChangedError struct {
+	previous string
+	current  string
+}
+
+func (e *hostKeyChangedError) Error() string {
+	return fmt.Sprintf("host key changed from %s to %s", e.previous, e.current)
+}
+
+// runSSHCheck conecta no servidor SSH (porta padrão 22), valida a versão anunciada e a
+// chave do host e, com chave privada configurada, autentica e executa SSHCommand.
+func runSSHCheck(m models.Monitor) CheckResult {
+	address, err := monitorAddress(m.URL, "22")
+	if err != nil {
+		return CheckResult{Status: models.MajorOutage, Reason: reasonConnectionError, Error: err.Error()}
+	}
+
+	// Sem chave o check não autentica; um comando configurado nunca rodaria.
+	if m.SSHCommand != "" && m.SSHPrivateKey == "" {
+		return CheckResult{Status: models.Unknown, Reason: reasonAuthFailed, Error: "sshCommand requires sshPrivateKey"}
+	}
+
+	var auth []ssh.AuthMethod
+	if m.SSHPrivateKey != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(m.SSHPrivateKey))
+		if err != nil {
+			return CheckResult{Status: models.Unknown, Reason: reasonAuthFailed, Error: fmt.Sprintf("invalid private key: %v", err)}
+		}
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+
+	timeout := monitorTimeout(m)
+	ctx, cancel := context.WithTimeout(client.Ctx, timeout)
+	defer cancel()
+
+	timings := map[string]int64{}
+	startTime := time.Now().UTC()
+
+	var dialer net.Dialer
+	rawConn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return sshFailure(m, startTime, timings, reasonConnectionError, err, nil)
+	}
+	defer rawConn.Close()
+	rawConn.SetDeadline(startTime.Add(timeout))
+	timings["connect"] = time.Since(startTime).Milliseconds()
+
+	conn := &bannerConn{Conn: rawConn}
+	var fingerprint string
+	config := &ssh.ClientConfig{
+		User: m.SSHUsername,
+		Auth: auth,
+		HostKeyCallback: func(_ string, _ net.Addr, key ssh.PublicKey) error {
+			fingerprint = ssh.FingerprintSHA256(key)
+			if err := verifySSHHostKey(m, fingerprint); err != nil {
+				return err
+			}
+			if len(auth) == 0 {
+				return errHostKeyCaptured
+			}
+			return nil
+		},
+		Timeout: timeout,
+	}
+
+	handshakeStart := time.Now()
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, address, config)
+	timings["handshake"] = time.Since(handshakeStart).Milliseconds()
+
+	evidence := map[string]interface{}{"banner": conn.banner()}
+	if fingerprint != "" {
+		evidence["fingerprint"] = fingerprint
+	}
+
+	var changed *hostKeyChangedError
+	switch {
+	case errors.As(err, &changed):
+		evidence["previousFingerprint"] = changed.previous
+		return sshFailure(m, startTime, timings, reasonHostKeyChanged, changed, evidence)
+	case errors.Is(err, errHostKeyCaptured):
+		// Sem credenciais: a verificação termina na chave do host.
+	case err != nil && fingerprint != "":
+		return sshFailure(m, startTime, timings, reasonAuthFailed, err, evidence)
+	case err != nil:
+		return sshFailure(m, startTime, timings, reasonConnectionError, err, evidence)
+	}
+
+	if m.SSHBanner != "" && !strings.Contains(conn.banner(), m.SSHBanner) {
+		err := fmt.Errorf("banner %q does not contain %q", conn.banner(), m.SSHBanner)
+		return sshFailure(m, startTime, timings, reasonUnexpectedResponse, err, evidence)
+	}
+
+	if sshConn == nil || m.SSHCommand == "" {
+		return CheckResult{Status: models.Operational, Latency: time.Since(startTime), Timings: timings, Evidence: evidence}
+	}
+
+	sshClient := ssh.NewClient(sshConn, chans, reqs)
+	defer sshClient.Close()
+
+	commandStart := time.Now()
+	session, err := sshClient.NewSession()
+	if err != nil {
+		return sshFailure(m, startTime, timings, reasonConnectionError, err, evidence)
+	}
+	defer session.Close()
+
+	output, err := session.CombinedOutput(m.SSHCommand)
+	timings["command"] = time.Since(commandStart).Milliseconds()
+	evidence["output"] = truncate(string(output), commandMaxOutput)
+
+	var exitErr *ssh.ExitError
+	if errors.As(err, &exitErr) {
+		evidence["exitCode"] = exitErr.ExitStatus()
+		return sshFailure(m, startTime, timings, reasonUnexpectedResponse, err, evidence)
+	} else if err != nil {
+		return sshFailure(m, startTime, timings, reasonConnectionError, err, evidence)
+	}
+
+	if m.SSHExpectedOutput != "" && !strings.Contains(string(output), m.SSHExpectedOutput) {
+		err := fmt.Errorf("command output does not contain %q", m.SSHExpectedOutput)
+		return sshFailure(m, startTime, timings, reasonAssertionFailed, err, evidence)
+	}
+
+	return CheckResult{Status: models.Operational, Latency: time.Since(startTime), Timings: timings, Evidence: evidence}
+}
+
+func sshFailure(m models.Monitor, startTime time.Time, timings map[string]int64, reason string, err error, evidence map[string]interface{}) CheckResult {
+	if isTimeout(err) {
+		reason = reasonTimeout
+	}
+	log.Printf("[HEALTH] Monitor %s (ID: %d) SSH check failed (%s): %v", m.Name, m.ID, reason, err)
+	return CheckResult{
+		Status:   models.MajorOutage,
+		Latency:  time.Since(startTime),
+		Reason:   reason,
+		Error:    err.Error(),
+		Timings:  timings,
+		Evidence: evidence,
+	}
+}
+
+// sshHostKeyKey guarda o fingerprint conhecido da chave do host do monitor.
+func sshHostKeyKey(monitorID int) string {
+	return fmt.Sprintf("monitor:%d:ssh_host_key", monitorID)
+}
+
+// verifySSHHostKey compara o fingerprint com o fixado em SSHHostKey ou, sem ele, com o
+// visto na primeira conexão. Uma chave diferente não é aceita automaticamente: para
+// aceitá-la, fixe o novo valor em sshHostKey ou remova a chave Redis do monitor.
+func verifySSHHostKey(m models.Monitor, fingerprint string) error {
+	rdb := client.ConnectRedis()
+	known, err := rdb.Get(client.Ctx, sshHostKeyKey(m.ID)).Result()
+	if err != nil && err != redis.Nil {
+		log.Printf("[REDIS] Error fetching SSH host key for monitor %s (ID: %d): %v", m.Name, m.ID, err)
+	}
+
+	expected := known
+	if m.SSHHostKey != "" {
+		expected = m.SSHHostKey
+	}
+	if expected != "" && expected != fingerprint {
+		return &hostKeyChangedError{previous: expected, current: fingerprint}
+	}
+
+	// Com erro de leitura no Redis, só o fingerprint fixado é verificado e nada é gravado.
+	if known != fingerprint && (err == nil || err == redis.Nil) {
+		log.Printf("[HEALTH] Monitor %s (ID: %d) storing SSH host key %s", m.Name, m.ID, fingerprint)
+		if err := rdb.Set(client.Ctx, sshHostKeyKey(m.ID), fingerprint, 0).Err(); err != nil {
+			log.Printf("[REDIS] Error storing SSH host key for monitor %s (ID: %d): %v", m.Name, m.ID, err)
+		}
+	}
+	return nil
+}
+
+// bannerConn guarda o início do que o servidor envia para extrair a linha de versão
+// (SSH-2.0-...), que a biblioteca não expõe quando o handshake é interrompido.
+type bannerConn struct {
+	net.Conn
+	buf []byte
+}
+
+const sshBannerCapture = 1024
+
+func (c *bannerConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	if room := sshBannerCapture - len(c.buf); room > 0 && n > 0 {
+		c.buf = append(c.buf, p[:min(n, room)]...)
+	}
+	return n, err
+}
+
+func (c *bannerConn) banner() string {
+	for _, line := range strings.Split(string(c.buf), "\n") {
+		if strings.HasPrefix(line, "SSH-") {
+			return strings.TrimRight(line, "\r")
+		}
+	}
+	return ""
+}
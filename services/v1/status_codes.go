@@ -0,0 +1,161 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"reacher-cron/config"
+	"reacher-cron/models"
+)
+
+var (
+	globalStatusCodeRules []models.StatusCodeRule
+	statusRulesOnce       sync.Once
+)
+
+// defaultExpectedStatus é usado quando o monitor não define expectedStatus.
+var defaultExpectedStatus = models.StatusCodeSpec{{Min: 200, Max: 299}}
+
+// parseStatusCodeSpec interpreta especificações como "200", "2xx", "200-299"
+// ou listas separadas por vírgula ("200,201,204", "2xx,301").
+func parseStatusCodeSpec(spec string) (models.StatusCodeSpec, error) {
+	var result models.StatusCodeSpec
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+
+		switch {
+		case len(part) == 3 && strings.HasSuffix(part, "xx"):
+			class, err := strconv.Atoi(part[:1])
+			if err != nil || class < 1 || class > 5 {
+				return nil, fmt.Errorf("invalid status class %q", part)
+			}
+			result = append(result, models.StatusCodeRange{Min: class * 100, Max: class*100 + 99})
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			min, err1 := strconv.Atoi(strings.TrimSpace(bounds[0]))
+			max, err2 := strconv.Atoi(strings.TrimSpace(bounds[1]))
+			if err1 != nil || err2 != nil || min > max {
+				return nil, fmt.Errorf("invalid status range %q", part)
+			}
+			result = append(result, models.StatusCodeRange{Min: min, Max: max})
+		default:
+			code, err := strconv.Atoi(part)
+			if err != nil {
+				return nil, fmt.Errorf("invalid status code %q", part)
+			}
+			result = append(result, models.StatusCodeRange{Min: code, Max: code})
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("empty status spec")
+	}
+	return result, nil
+}
+
+// parseStatusCodeRules interpreta um objeto JSON no formato {"<spec>": "<status>"},
+// onde spec segue o mesmo formato de expectedStatus. Como vence o intervalo mais
+// específico, dois intervalos de mesma largura que se sobrepõem com status diferentes
+// são rejeitados: o resultado dependeria da ordem das chaves.
+func parseStatusCodeRules(raw string) ([]models.StatusCodeRule, error) {
+	var table map[string]models.Status
+	if err := json.Unmarshal([]byte(raw), &table); err != nil {
+		return nil, err
+	}
+
+	specs := make([]string, 0, len(table))
+	for spec := range table {
+		specs = append(specs, spec)
+	}
+	sort.Strings(specs)
+
+	rules := make([]models.StatusCodeRule, 0, len(table))
+	for _, spec := range specs {
+		status := table[spec]
+		switch status {
+		case models.ServiceDegraded, models.PartialOutage, models.MajorOutage:
+		default:
+			return nil, fmt.Errorf("invalid status %q for %q", status, spec)
+		}
+		codes, err := parseStatusCodeSpec(spec)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, models.StatusCodeRule{Codes: codes, Status: status})
+	}
+
+	for i := range rules {
+		for j := i + 1; j < len(rules); j++ {
+			if rules[i].Status != rules[j].Status && ambiguousStatusRanges(rules[i].Codes, rules[j].Codes) {
+				return nil, fmt.Errorf("rules %q and %q overlap with ranges of the same width", specs[i], specs[j])
+			}
+		}
+	}
+	return rules, nil
+}
+
+// ambiguousStatusRanges informa se algum intervalo de a se sobrepõe a um de b com a mesma largura.
+func ambiguousStatusRanges(a, b models.StatusCodeSpec) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x.Max-x.Min == y.Max-y.Min && x.Min <= y.Max && y.Min <= x.Max {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getGlobalStatusCodeRules carrega uma única vez a tabela global do config.
+func getGlobalStatusCodeRules() []models.StatusCodeRule {
+	statusRulesOnce.Do(func() {
+		if config.AppConfig.StatusCodeClassification == "" {
+			return
+		}
+		rules, err := parseStatusCodeRules(config.AppConfig.StatusCodeClassification)
+		if err != nil {
+			log.Printf("[MONITOR] Invalid STATUS_CODE_CLASSIFICATION, ignoring: %v", err)
+			return
+		}
+		globalStatusCodeRules = rules
+	})
+	return globalStatusCodeRules
+}
+
+// classifyStatusCode define o status de um código HTTP inesperado.
+// As regras do monitor têm prioridade sobre as globais; dentro de cada tabela
+// vence a regra mais específica (menor intervalo). Sem regra, é major_outage.
+func classifyStatusCode(m models.Monitor, code int) models.Status {
+	for _, rules := range [][]models.StatusCodeRule{m.StatusCodeRules, getGlobalStatusCodeRules()} {
+		if status, ok := matchStatusCodeRule(rules, code); ok {
+			return status
+		}
+	}
+	return models.MajorOutage
+}
+
+func matchStatusCodeRule(rules []models.StatusCodeRule, code int) (models.Status, bool) {
+	var match models.Status
+	bestWidth := -1
+	for _, rule := range rules {
+		for _, r := range rule.Codes {
+			if code < r.Min || code > r.Max {
+				continue
+			}
+			if width := r.Max - r.Min; bestWidth == -1 || width < bestWidth {
+				bestWidth = width
+				match = rule.Status
+			}
+		}
+	}
+	return match, bestWidth != -1
+}
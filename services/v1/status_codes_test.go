@@ -0,0 +1,112 @@
+package v1
+
+import (
+	"reflect"
+	"testing"
+
+	"reacher-cron/models"
+)
+
+func TestParseStatusCodeSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    models.StatusCodeSpec
+		wantErr bool
+	}{
+		{"single code", "200", models.StatusCodeSpec{{Min: 200, Max: 200}}, false},
+		{"class", "2xx", models.StatusCodeSpec{{Min: 200, Max: 299}}, false},
+		{"class uppercase", "5XX", models.StatusCodeSpec{{Min: 500, Max: 599}}, false},
+		{"range", "200-299", models.StatusCodeSpec{{Min: 200, Max: 299}}, false},
+		{"range with spaces", " 301 - 302 ", models.StatusCodeSpec{{Min: 301, Max: 302}}, false},
+		{"list", "200,201,204", models.StatusCodeSpec{{Min: 200, Max: 200}, {Min: 201, Max: 201}, {Min: 204, Max: 204}}, false},
+		{"mixed list", "2xx, 301, 400-404", models.StatusCodeSpec{{Min: 200, Max: 299}, {Min: 301, Max: 301}, {Min: 400, Max: 404}}, false},
+		{"empty items ignored", "200,,", models.StatusCodeSpec{{Min: 200, Max: 200}}, false},
+		{"invalid class", "6xx", nil, true},
+		{"non-numeric class", "axx", nil, true},
+		{"inverted range", "299-200", nil, true},
+		{"open range", "200-", nil, true},
+		{"garbage", "ok", nil, true},
+		{"empty", "", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseStatusCodeSpec(tt.spec)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseStatusCodeSpec(%q) = %v, want error", tt.spec, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseStatusCodeSpec(%q) unexpected error: %v", tt.spec, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseStatusCodeSpec(%q) = %v, want %v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusCodeSpecMatches(t *testing.T) {
+	spec, err := parseStatusCodeSpec("2xx,301,400-404")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for code, want := range map[int]bool{200: true, 299: true, 301: true, 302: false, 404: true, 405: false, 500: false} {
+		if got := spec.Matches(code); got != want {
+			t.Errorf("Matches(%d) = %v, want %v", code, got, want)
+		}
+	}
+}
+
+func TestMatchStatusCodeRuleMostSpecific(t *testing.T) {
+	rules, err := parseStatusCodeRules(`{"4xx":"major_outage","429":"service_degraded"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		code   int
+		want   models.Status
+		wantOK bool
+	}{
+		{429, models.ServiceDegraded, true},
+		{404, models.MajorOutage, true},
+		{503, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := matchStatusCodeRule(rules, tt.code)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("matchStatusCodeRule(%d) = (%q, %v), want (%q, %v)", tt.code, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestParseStatusCodeRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		wantErr bool
+	}{
+		{"nested ranges", `{"4xx":"major_outage","429":"service_degraded"}`, false},
+		{"disjoint ranges", `{"500-504":"partial_outage","505-509":"major_outage"}`, false},
+		{"same width overlap, same status", `{"500-509":"major_outage","505-514":"major_outage"}`, false},
+		{"same width overlap, different status", `{"500-509":"partial_outage","505-514":"major_outage"}`, true},
+		{"same code twice", `{"503":"partial_outage","429,503":"service_degraded"}`, true},
+		{"invalid status", `{"503":"down"}`, true},
+		{"invalid spec", `{"abc":"major_outage"}`, true},
+		{"not an object", `["503"]`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseStatusCodeRules(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseStatusCodeRules(%s) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"reacher-cron/client"
+	"reacher-cron/models"
+)
+
+func init() {
+	RegisterChecker(models.MonitorTypeTCP, CheckerFunc(runTCPCheck))
+}
+
+// Limite de bytes lidos da resposta/banner TCP.
+const tcpMaxResponseBytes = 4096
+
+// runTCPCheck abre uma conexão TCP com o alvo do monitor, medindo a latência de conexão.
+// Opcionalmente envia m.Payload e espera que a resposta contenha m.ExpectedResponse.
+func runTCPCheck(m models.Monitor) CheckResult {
+	address, err := monitorAddress(m.URL, "")
+	if err != nil {
+		return CheckResult{Status: models.MajorOutage, Reason: reasonConnectionError, Error: err.Error()}
+	}
+
+	timeout := monitorTimeout(m)
+	ctx, cancel := context.WithTimeout(client.Ctx, timeout)
+	defer cancel()
+
+	startTime := time.Now().UTC()
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	connectTime := time.Since(startTime)
+	if err != nil {
+		reason := reasonConnectionError
+		if isTimeout(err) {
+			reason = reasonTimeout
+		}
+		log.Printf("[HEALTH] Monitor %s (ID: %d) TCP connect to %s failed (%s): %v", m.Name, m.ID, address, reason, err)
+		return CheckResult{Status: models.MajorOutage, Latency: connectTime, Reason: reason, Error: err.Error()}
+	}
+	defer conn.Close()
+
+	details := map[string]interface{}{"connectTime": connectTime.Milliseconds()}
+	if m.Payload == "" && m.ExpectedResponse == "" {
+		return CheckResult{Status: models.Operational, Latency: connectTime, Evidence: details}
+	}
+
+	payload, expected, err := decodePayloads(m)
+	if err != nil {
+		return CheckResult{Status: models.MajorOutage, Latency: connectTime, Reason: reasonUnexpectedResponse, Error: err.Error(), Evidence: details}
+	}
+
+	conn.SetDeadline(startTime.Add(timeout))
+
+	if len(payload) > 0 {
+		if _, err := conn.Write(payload); err != nil {
+			return tcpFailure(m, startTime, details, err)
+		}
+	}
+
+	var response []byte
+	if len(expected) > 0 {
+		response, err = readUntilContains(conn, expected, tcpMaxResponseBytes)
+		details["response"] = formatPayload(m.PayloadEncoding, response)
+		if err != nil {
+			return tcpFailure(m, startTime, details, err)
+		}
+	}
+
+	return CheckResult{Status: models.Operational, Latency: time.Since(startTime), Evidence: details}
+}
+
+func tcpFailure(m models.Monitor, startTime time.Time, details map[string]interface{}, err error) CheckResult {
+	reason := reasonUnexpectedResponse
+	if isTimeout(err) {
+		reason = reasonTimeout
+	}
+	log.Printf("[HEALTH] Monitor %s (ID: %d) TCP exchange failed (%s): %v", m.Name, m.ID, reason, err)
+	return CheckResult{Status: models.MajorOutage, Latency: time.Since(startTime), Reason: reason, Error: err.Error(), Evidence: details}
+}
+
+// readUntilContains lê da conexão até encontrar o trecho esperado,
+// atingir o limite de bytes ou o deadline da conexão.
+func readUntilContains(conn net.Conn, expected []byte, limit int) ([]byte, error) {
+	buf := make([]byte, 0, 512)
+	chunk := make([]byte, 512)
+	for len(buf) < limit {
+		n, err := conn.Read(chunk)
+		buf = append(buf, chunk[:n]...)
+		if bytes.Contains(buf, expected) {
+			return buf, nil
+		}
+		if err != nil {
+			return buf, fmt.Errorf("expected response %q not received: %w", expected, err)
+		}
+	}
+	return buf, fmt.Errorf("expected response %q not found in first %d bytes", expected, limit)
+}
+
+// monitorAddress extrai host:port do alvo do monitor, aceitando "host:port"
+// ou URLs como "tcp://host:port". defaultPort é usado quando a porta não é informada.
+func monitorAddress(target string, defaultPort string) (string, error) {
+	host := strings.TrimSpace(target)
+	if strings.Contains(host, "://") {
+		u, err := url.Parse(host)
+		if err != nil {
+			return "", fmt.Errorf("invalid target %q: %v", target, err)
+		}
+		host = u.Host
+	}
+
+	if _, port, err := net.SplitHostPort(host); err == nil && port != "" {
+		return host, nil
+	}
+	if defaultPort == "" {
+		return "", fmt.Errorf("target %q must include a port", target)
+	}
+	return net.JoinHostPort(strings.Trim(host, "[]"), defaultPort), nil
+}
+
+// decodePayloads converte m.Payload e m.ExpectedResponse conforme m.PayloadEncoding:
+// hex ("0a ff", "0aff") ou texto, onde \r, \n e \t escritos literalmente são convertidos.
+func decodePayloads(m models.Monitor) ([]byte, []byte, error) {
+	decode := func(v string) ([]byte, error) {
+		if m.PayloadEncoding == "hex" {
+			return hex.DecodeString(strings.Join(strings.Fields(v), ""))
+		}
+		return []byte(strings.NewReplacer(`\r`, "\r", `\n`, "\n", `\t`, "\t").Replace(v)), nil
+	}
+
+	payload, err := decode(m.Payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid hex payload: %v", err)
+	}
+	expected, err := decode(m.ExpectedResponse)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid hex expected response: %v", err)
+	}
+	return payload, expected, nil
+}
+
+// formatPayload representa a resposta recebida no histórico, em hex quando for o encoding do monitor.
+func formatPayload(encoding string, data []byte) string {
+	if encoding == "hex" {
+		return hex.EncodeToString(data)
+	}
+	return string(data)
+}
@@ -0,0 +1,173 @@
+package v1
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	"reacher-cron/client"
+	"reacher-cron/config"
+	"reacher-cron/models"
+)
+
+func init() {
+	RegisterChecker(models.MonitorTypeTLS, CheckerFunc(runTLSCheck))
+}
+
+// runTLSCheck faz o handshake TLS com o alvo do monitor (porta padrão 443),
+// valida a cadeia e o hostname e classifica a proximidade da expiração.
+func runTLSCheck(m models.Monitor) CheckResult {
+	address, err := monitorAddress(m.URL, "443")
+	if err != nil {
+		return CheckResult{Status: models.MajorOutage, Reason: reasonConnectionError, Error: err.Error()}
+	}
+	host, _, _ := net.SplitHostPort(address)
+
+	ctx, cancel := context.WithTimeout(client.Ctx, monitorTimeout(m))
+	defer cancel()
+
+	// A verificação é feita manualmente para registrar os detalhes mesmo com certificado inválido.
+	dialer := &tls.Dialer{Config: &tls.Config{ServerName: host, InsecureSkipVerify: true}}
+
+	startTime := time.Now().UTC()
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	duration := time.Since(startTime)
+	if err != nil {
+		reason := reasonConnectionError
+		if isTimeout(err) {
+			reason = reasonTimeout
+		}
+		log.Printf("[HEALTH] Monitor %s (ID: %d) TLS handshake with %s failed (%s): %v", m.Name, m.ID, address, reason, err)
+		return CheckResult{Status: models.MajorOutage, Latency: duration, Reason: reason, Error: err.Error()}
+	}
+	defer conn.Close()
+
+	state := conn.(*tls.Conn).ConnectionState()
+	result := CheckResult{Status: models.Operational, Latency: duration}
+
+	if err := verifyCertificate(state, host); err != nil {
+		log.Printf("[HEALTH] Monitor %s (ID: %d) TLS certificate invalid: %v", m.Name, m.ID, err)
+		result.Status = models.MajorOutage
+		result.Reason = reasonCertInvalid
+		result.Error = err.Error()
+		result.Evidence = map[string]interface{}{"certificate": certificateDetails(state)}
+		return result
+	}
+
+	applyCertificateStatus(m, &result, state)
+	return result
+}
+
+// applyCertificateStatus grava os dados do certificado no resultado e rebaixa
+// o status conforme os limites de dias até a expiração.
+func applyCertificateStatus(m models.Monitor, result *CheckResult, state tls.ConnectionState) {
+	if len(state.PeerCertificates) == 0 {
+		return
+	}
+	if result.Evidence == nil {
+		result.Evidence = map[string]interface{}{}
+	}
+	result.Evidence["certificate"] = certificateDetails(state)
+
+	days := certificateDaysToExpiry(state.PeerCertificates[0])
+	status := classifyCertificateExpiry(m, days)
+	if statusSeverity(status) <= statusSeverity(result.Status) {
+		return
+	}
+
+	log.Printf("[HEALTH] Monitor %s (ID: %d) certificate expires in %d days, classified as %s", m.Name, m.ID, days, status)
+	result.Status = status
+	result.Reason = reasonCertExpiring
+	result.Error = fmt.Sprintf("certificate expires in %d days (%s)", days, state.PeerCertificates[0].NotAfter.UTC().Format(time.RFC3339))
+}
+
+// classifyCertificateExpiry converte os dias restantes em status, usando os
+// limites do monitor ou os globais. Certificado expirado é sempre major_outage.
+func classifyCertificateExpiry(m models.Monitor, days int) models.Status {
+	threshold := func(v *int, fallback int) int {
+		if v != nil {
+			return *v
+		}
+		return fallback
+	}
+
+	switch {
+	case days < 0 || days <= threshold(m.CertMajorOutageDays, config.AppConfig.CertMajorOutageDays):
+		return models.MajorOutage
+	case days <= threshold(m.CertPartialOutageDays, config.AppConfig.CertPartialOutageDays):
+		return models.PartialOutage
+	case days <= threshold(m.CertDegradedDays, config.AppConfig.CertDegradedDays):
+		return models.ServiceDegraded
+	}
+	return models.Operational
+}
+
+// verifyCertificate valida a cadeia apresentada e o hostname.
+func verifyCertificate(state tls.ConnectionState, host string) error {
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("no peer certificate presented")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		DNSName:       host,
+		Intermediates: intermediates,
+	})
+	return err
+}
+
+// certificateDetails resume o certificado e a cadeia para o histórico.
+func certificateDetails(state tls.ConnectionState) map[string]interface{} {
+	if len(state.PeerCertificates) == 0 {
+		return nil
+	}
+	leaf := state.PeerCertificates[0]
+
+	chain := make([]map[string]interface{}, 0, len(state.PeerCertificates))
+	for _, cert := range state.PeerCertificates {
+		chain = append(chain, map[string]interface{}{
+			"subject":  cert.Subject.String(),
+			"issuer":   cert.Issuer.String(),
+			"notAfter": cert.NotAfter.UTC().Format(time.RFC3339),
+		})
+	}
+
+	return map[string]interface{}{
+		"subject":      leaf.Subject.String(),
+		"issuer":       leaf.Issuer.String(),
+		"dnsNames":     leaf.DNSNames,
+		"notBefore":    leaf.NotBefore.UTC().Format(time.RFC3339),
+		"notAfter":     leaf.NotAfter.UTC().Format(time.RFC3339),
+		"daysToExpiry": certificateDaysToExpiry(leaf),
+		"tlsVersion":   tls.VersionName(state.Version),
+		"chain":        chain,
+	}
+}
+
+// certificateDaysToExpiry retorna os dias inteiros restantes; negativo se já expirou.
+func certificateDaysToExpiry(cert *x509.Certificate) int {
+	remaining := time.Until(cert.NotAfter)
+	if remaining < 0 {
+		return int(remaining.Hours()/24) - 1
+	}
+	return int(remaining.Hours() / 24)
+}
+
+// isCertificateError indica se o erro veio da validação do certificado.
+func isCertificateError(err error) bool {
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostname x509.HostnameError
+	var invalid x509.CertificateInvalidError
+	var verification *tls.CertificateVerificationError
+	return errors.As(err, &unknownAuthority) || errors.As(err, &hostname) ||
+		errors.As(err, &invalid) || errors.As(err, &verification)
+}
@@ -0,0 +1,243 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"reacher-cron/client"
+	"reacher-cron/config"
+	"reacher-cron/models"
+)
+
+func init() {
+	RegisterChecker(models.MonitorTypeTransaction, CheckerFunc(runTransactionCheck))
+}
+
+// Variáveis no formato {{nome}} dentro de URL, cabeçalhos e corpo dos passos.
+var templateVarPattern = regexp.MustCompile(`\{\{\s*([\w.-]+)\s*\}\}`)
+
+// stepResult é o resumo de um passo gravado no histórico.
+type stepResult struct {
+	Name       string `json:"name"`
+	StatusCode int    `json:"statusCode,omitempty"`
+	Duration   int64  `json:"duration"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+}
+
+// runTransactionCheck executa os passos do monitor em ordem, compartilhando cookies
+// e variáveis extraídas. A execução para no primeiro passo que falhar. O timeout do
+// monitor vale para a transação inteira, não para cada passo.
+func runTransactionCheck(m models.Monitor) CheckResult {
+	if len(m.Steps) == 0 {
+		return CheckResult{Status: models.MajorOutage, Reason: reasonAssertionFailed, Error: "transaction has no steps"}
+	}
+
+	ctx, cancel := context.WithTimeout(client.Ctx, monitorTimeout(m))
+	defer cancel()
+
+	// Cliente da execução: mesmo transport compartilhado, com cookie jar próprio.
+	jar, _ := cookiejar.New(nil)
+	httpClient := &http.Client{Transport: client.GetHTTPClient().Transport, Jar: jar}
+
+	vars := map[string]string{}
+	steps := make([]stepResult, 0, len(m.Steps))
+	evidence := map[string]interface{}{}
+	startTime := time.Now().UTC()
+
+	for i, step := range m.Steps {
+		name := step.Name
+		if name == "" {
+			name = fmt.Sprintf("step %d", i+1)
+		}
+
+		result, statusCode := runTransactionStep(ctx, m, step, vars, httpClient)
+		steps = append(steps, stepResult{
+			Name:       name,
+			StatusCode: statusCode,
+			Duration:   result.Latency.Milliseconds(),
+			Status:     string(result.Status),
+			Error:      result.Error,
+		})
+		evidence["steps"] = steps
+
+		if result.Status != models.Operational {
+			evidence["failedStep"] = name
+			log.Printf("[HEALTH] Monitor %s (ID: %d) transaction failed at %q (%s): %s", m.Name, m.ID, name, result.Reason, result.Error)
+			return CheckResult{
+				Status:   result.Status,
+				Latency:  time.Since(startTime),
+				Reason:   result.Reason,
+				Error:    fmt.Sprintf("step %d %q failed: %s", i+1, name, result.Error),
+				Evidence: evidence,
+			}
+		}
+	}
+
+	return CheckResult{Status: models.Operational, Latency: time.Since(startTime), Evidence: evidence}
+}
+
+// runTransactionStep executa um passo reaproveitando a montagem de requisição e a
+// avaliação de resposta dos monitores HTTP, e extrai as variáveis configuradas.
+func runTransactionStep(ctx context.Context, m models.Monitor, step models.TransactionStep, vars map[string]string, httpClient *http.Client) (CheckResult, int) {
+	stepMonitor := m
+	stepMonitor.URL = applyURLTemplate(step.URL, vars)
+	stepMonitor.Method = step.Method
+	stepMonitor.BodyType = step.BodyType
+	stepMonitor.BodyContains = step.BodyContains
+	stepMonitor.BodyNotContains = nil
+	stepMonitor.BodyRegex = ""
+	stepMonitor.JSONAssertions = step.JSONAssertions
+	stepMonitor.Body = nil
+	if step.Body != "" {
+		body := applyTemplate(step.Body, vars)
+		stepMonitor.Body = &body
+	}
+	stepMonitor.Headers = make(map[string]string, len(step.Headers))
+	for key, value := range step.Headers {
+		stepMonitor.Headers[key] = applyTemplate(value, vars)
+	}
+	stepMonitor.ExpectedStatus = defaultExpectedStatus
+	if step.ExpectedStatus != "" {
+		spec, err := parseStatusCodeSpec(step.ExpectedStatus)
+		if err != nil {
+			return CheckResult{Status: models.Unknown, Reason: reasonInvalidConfig, Error: err.Error()}, 0
+		}
+		stepMonitor.ExpectedStatus = spec
+	}
+
+	// Erros de configuração não são transitórios: não devem ser repetidos nem abrir incidentes.
+	req, err := buildHTTPRequest(ctx, stepMonitor)
+	if err != nil {
+		return CheckResult{Status: models.Unknown, Reason: reasonInvalidConfig, Error: err.Error()}, 0
+	}
+
+	startTime := time.Now().UTC()
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		reason := reasonConnectionError
+		if isTimeout(err) {
+			reason = reasonTimeout
+		}
+		return CheckResult{Status: models.MajorOutage, Latency: time.Since(startTime), Reason: reason, Error: err.Error()}, 0
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, config.AppConfig.HTTPMaxBodyBytes))
+	duration := time.Since(startTime)
+	if err != nil {
+		return CheckResult{Status: models.MajorOutage, Latency: duration, Reason: reasonConnectionError, Error: err.Error()}, resp.StatusCode
+	}
+
+	result := evaluateHTTPResponse(stepMonitor, resp, body, duration)
+	if result.Status != models.Operational {
+		return result, resp.StatusCode
+	}
+
+	for _, extraction := range step.Extract {
+		value, err := extractStepValue(extraction, resp, body)
+		if err != nil {
+			return CheckResult{Status: models.MajorOutage, Latency: duration, Reason: reasonExtractionFailed, Error: err.Error()}, resp.StatusCode
+		}
+		vars[extraction.Name] = value
+	}
+
+	return result, resp.StatusCode
+}
+
+// extractStepValue lê um valor da resposta conforme a origem configurada.
+func extractStepValue(e models.StepExtraction, resp *http.Response, body []byte) (string, error) {
+	switch e.Source {
+	case "json":
+		var doc interface{}
+		if err := json.Unmarshal(body, &doc); err != nil {
+			return "", fmt.Errorf("extract %q: response body is not valid JSON", e.Name)
+		}
+		value, found, err := lookupJSONPath(doc, e.Path)
+		if err != nil {
+			return "", fmt.Errorf("extract %q: %v", e.Name, err)
+		}
+		if !found {
+			return "", fmt.Errorf("extract %q: path %s not found", e.Name, e.Path)
+		}
+		return jsonValueString(value), nil
+	case "header":
+		value := resp.Header.Get(e.Path)
+		if value == "" {
+			return "", fmt.Errorf("extract %q: header %s not found", e.Name, e.Path)
+		}
+		return value, nil
+	case "regex":
+		re, err := regexp.Compile(e.Path)
+		if err != nil {
+			return "", fmt.Errorf("extract %q: invalid regex: %v", e.Name, err)
+		}
+		match := re.FindSubmatch(body)
+		if match == nil {
+			return "", fmt.Errorf("extract %q: regex %q did not match", e.Name, e.Path)
+		}
+		if len(match) > 1 {
+			return string(match[1]), nil
+		}
+		return string(match[0]), nil
+	default:
+		return "", fmt.Errorf("extract %q: unsupported source %q", e.Name, e.Source)
+	}
+}
+
+// applyTemplate substitui {{nome}} pelas variáveis extraídas; nomes desconhecidos são mantidos.
+func applyTemplate(text string, vars map[string]string) string {
+	return templateVarPattern.ReplaceAllStringFunc(text, func(match string) string {
+		name := templateVarPattern.FindStringSubmatch(match)[1]
+		if value, ok := vars[name]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+// applyURLTemplate substitui as variáveis na URL de um passo escapando cada valor
+// conforme a posição: no caminho com PathEscape, na query com QueryEscape. Uma variável
+// no início da URL ou antes do caminho (esquema/host) é inserida sem escape.
+func applyURLTemplate(rawURL string, vars map[string]string) string {
+	pathStart := 0
+	if i := strings.Index(rawURL, "://"); i != -1 {
+		pathStart = len(rawURL)
+		if j := strings.IndexAny(rawURL[i+3:], "/?#"); j != -1 {
+			pathStart = i + 3 + j
+		}
+	}
+	queryStart := strings.IndexAny(rawURL, "?#")
+	if queryStart == -1 {
+		queryStart = len(rawURL)
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, loc := range templateVarPattern.FindAllStringSubmatchIndex(rawURL, -1) {
+		b.WriteString(rawURL[last:loc[0]])
+		last = loc[1]
+
+		value, ok := vars[rawURL[loc[2]:loc[3]]]
+		switch {
+		case !ok:
+			value = rawURL[loc[0]:loc[1]]
+		case loc[0] >= queryStart:
+			value = url.QueryEscape(value)
+		case loc[0] > 0 && loc[0] >= pathStart:
+			value = url.PathEscape(value)
+		}
+		b.WriteString(value)
+	}
+	b.WriteString(rawURL[last:])
+	return b.String()
+}
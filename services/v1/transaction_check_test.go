@@ -0,0 +1,32 @@
+package v1
+
+import "testing"
+
+func TestApplyURLTemplate(t *testing.T) {
+	vars := map[string]string{
+		"token": "a+b/c&d=e",
+		"id":    "42/x",
+		"base":  "https://api.example.com/v1",
+	}
+
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{"query value", "https://example.com/items?token={{token}}", "https://example.com/items?token=a%2Bb%2Fc%26d%3De"},
+		{"path segment", "https://example.com/items/{{id}}/detail", "https://example.com/items/42%2Fx/detail"},
+		{"relative path", "/items/{{id}}", "/items/42%2Fx"},
+		{"full URL at start", "{{base}}/items?token={{token}}", "https://api.example.com/v1/items?token=a%2Bb%2Fc%26d%3De"},
+		{"unknown variable kept", "https://example.com/{{missing}}", "https://example.com/{{missing}}"},
+		{"no variables", "https://example.com/health", "https://example.com/health"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := applyURLTemplate(tt.url, vars); got != tt.want {
+				t.Errorf("applyURLTemplate(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"regexp"
+	"syscall"
+	"time"
+
+	"reacher-cron/client"
+	"reacher-cron/models"
+)
+
+func init() {
+	RegisterChecker(models.MonitorTypeUDP, CheckerFunc(runUDPCheck))
+}
+
+// Tamanho máximo de um datagrama de resposta.
+const udpMaxDatagram = 65535
+
+// runUDPCheck envia m.Payload (texto ou hex) para host:port e espera, dentro do timeout,
+// um datagrama que contenha m.ExpectedResponse e case com m.ExpectedResponseRegex. Sem
+// resposta esperada configurada, basta receber qualquer datagrama.
+func runUDPCheck(m models.Monitor) CheckResult {
+	address, err := monitorAddress(m.URL, "")
+	if err != nil {
+		return CheckResult{Status: models.MajorOutage, Reason: reasonConnectionError, Error: err.Error()}
+	}
+
+	payload, expected, err := decodePayloads(m)
+	if err != nil {
+		return CheckResult{Status: models.MajorOutage, Reason: reasonUnexpectedResponse, Error: err.Error()}
+	}
+	pattern := m.ExpectedResponsePattern
+	if pattern == nil && m.ExpectedResponseRegex != "" {
+		if pattern, err = regexp.Compile(m.ExpectedResponseRegex); err != nil {
+			return CheckResult{Status: models.Unknown, Reason: reasonInvalidConfig, Error: fmt.Sprintf("invalid expected response regex: %v", err)}
+		}
+	}
+	if len(payload) == 0 {
+		return CheckResult{Status: models.MajorOutage, Reason: reasonUnexpectedResponse, Error: "UDP monitors require a payload"}
+	}
+
+	timeout := monitorTimeout(m)
+	ctx, cancel := context.WithTimeout(client.Ctx, timeout)
+	defer cancel()
+
+	startTime := time.Now().UTC()
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", address)
+	if err != nil {
+		return udpFailure(m, startTime, nil, reasonConnectionError, err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(startTime.Add(timeout))
+
+	if _, err := conn.Write(payload); err != nil {
+		return udpFailure(m, startTime, nil, reasonConnectionError, err)
+	}
+
+	// Lê datagramas até um conter a resposta esperada ou o deadline estourar.
+	evidence := map[string]interface{}{}
+	buf := make([]byte, udpMaxDatagram)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			reason := reasonUnexpectedResponse
+			if errors.Is(err, syscall.ECONNREFUSED) {
+				// ICMP port unreachable: ninguém escuta na porta.
+				reason = reasonConnectionError
+			}
+			return udpFailure(m, startTime, evidence, reason, fmt.Errorf("expected reply not received: %w", err))
+		}
+		evidence["response"] = truncate(formatPayload(m.PayloadEncoding, buf[:n]), 512)
+		if udpResponseMatches(m.PayloadEncoding, buf[:n], expected, pattern) {
+			break
+		}
+	}
+
+	return CheckResult{Status: models.Operational, Latency: time.Since(startTime), Evidence: evidence}
+}
+
+// udpResponseMatches verifica o datagrama contra o trecho esperado e a expressão regular,
+// que em encoding hex é aplicada à representação hex da resposta.
+func udpResponseMatches(encoding string, data, expected []byte, pattern *regexp.Regexp) bool {
+	if !bytes.Contains(data, expected) {
+		return false
+	}
+	if pattern == nil {
+		return true
+	}
+	if encoding == "hex" {
+		return pattern.MatchString(hex.EncodeToString(data))
+	}
+	return pattern.Match(data)
+}
+
+func udpFailure(m models.Monitor, startTime time.Time, evidence map[string]interface{}, reason string, err error) CheckResult {
+	if isTimeout(err) {
+		reason = reasonTimeout
+	}
+	log.Printf("[HEALTH] Monitor %s (ID: %d) UDP check failed (%s): %v", m.Name, m.ID, reason, err)
+	return CheckResult{Status: models.MajorOutage, Latency: time.Since(startTime), Reason: reason, Error: err.Error(), Evidence: evidence}
+}
@@ -0,0 +1,39 @@
+package v1
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestUDPResponseMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding string
+		data     []byte
+		expected []byte
+		pattern  string
+		want     bool
+	}{
+		{"any datagram", "", []byte("pong"), nil, "", true},
+		{"contains text", "", []byte("PONG 1"), []byte("PONG"), "", true},
+		{"missing text", "", []byte("ERR"), []byte("PONG"), "", false},
+		{"text regex", "", []byte("version 2.4"), nil, `^version \d+\.\d+$`, true},
+		{"text regex mismatch", "", []byte("version x"), nil, `^version \d+`, false},
+		{"hex bytes", "hex", []byte{0x0a, 0x01, 0xff}, []byte{0x01, 0xff}, "", true},
+		{"hex regex with wildcard", "hex", []byte{0x0a, 0x42, 0xff}, nil, `^0a..ff$`, true},
+		{"hex regex mismatch", "hex", []byte{0x0b, 0x42, 0xff}, nil, `^0a..ff$`, false},
+		{"both must match", "", []byte("PONG v1"), []byte("PONG"), `v2`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pattern *regexp.Regexp
+			if tt.pattern != "" {
+				pattern = regexp.MustCompile(tt.pattern)
+			}
+			if got := udpResponseMatches(tt.encoding, tt.data, tt.expected, pattern); got != tt.want {
+				t.Errorf("udpResponseMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
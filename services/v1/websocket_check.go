@@ -0,0 +1,107 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"reacher-cron/client"
+	"reacher-cron/models"
+
+	"github.com/gorilla/websocket"
+)
+
+func init() {
+	RegisterChecker(models.MonitorTypeWebSocket, CheckerFunc(runWebSocketCheck))
+}
+
+// runWebSocketCheck faz o handshake de upgrade com o alvo (ws:// ou wss://) e,
+// se configurado, envia m.Payload e espera uma resposta contendo m.ExpectedResponse.
+func runWebSocketCheck(m models.Monitor) CheckResult {
+	timeout := monitorTimeout(m)
+	ctx, cancel := context.WithTimeout(client.Ctx, timeout)
+	defer cancel()
+
+	header := http.Header{}
+	for key, value := range m.Headers {
+		header.Set(key, value)
+	}
+
+	dialer := websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: timeout,
+	}
+
+	startTime := time.Now().UTC()
+	conn, resp, err := dialer.DialContext(ctx, m.URL, header)
+	handshake := time.Since(startTime)
+	timings := map[string]int64{"handshake": handshake.Milliseconds()}
+
+	if err != nil {
+		reason := reasonConnectionError
+		if isTimeout(err) {
+			reason = reasonTimeout
+		} else if isCertificateError(err) {
+			reason = reasonCertInvalid
+		} else if resp != nil {
+			// O servidor respondeu, mas recusou o upgrade.
+			reason = reasonUnexpectedStatus
+			err = fmt.Errorf("%v (status %d)", err, resp.StatusCode)
+		}
+		log.Printf("[HEALTH] Monitor %s (ID: %d) WebSocket handshake failed (%s): %v", m.Name, m.ID, reason, err)
+		return CheckResult{Status: models.MajorOutage, Latency: handshake, Reason: reason, Error: err.Error(), Timings: timings}
+	}
+	defer conn.Close()
+
+	if m.Payload == "" && m.ExpectedResponse == "" {
+		conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+		return CheckResult{Status: models.Operational, Latency: handshake, Timings: timings}
+	}
+
+	conn.SetWriteDeadline(startTime.Add(timeout))
+	conn.SetReadDeadline(startTime.Add(timeout))
+
+	exchangeStart := time.Now()
+	if m.Payload != "" {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(m.Payload)); err != nil {
+			return webSocketFailure(m, startTime, timings, nil, err)
+		}
+	}
+
+	// Lê mensagens até encontrar a resposta esperada ou estourar o timeout.
+	evidence := map[string]interface{}{}
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return webSocketFailure(m, startTime, timings, evidence, fmt.Errorf("expected reply %q not received: %w", m.ExpectedResponse, err))
+		}
+		evidence["reply"] = truncate(string(message), 512)
+		if strings.Contains(string(message), m.ExpectedResponse) {
+			break
+		}
+	}
+	timings["roundtrip"] = time.Since(exchangeStart).Milliseconds()
+
+	conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+	return CheckResult{Status: models.Operational, Latency: time.Since(startTime), Timings: timings, Evidence: evidence}
+}
+
+func webSocketFailure(m models.Monitor, startTime time.Time, timings map[string]int64, evidence map[string]interface{}, err error) CheckResult {
+	reason := reasonUnexpectedResponse
+	if isTimeout(err) {
+		reason = reasonTimeout
+	}
+	log.Printf("[HEALTH] Monitor %s (ID: %d) WebSocket exchange failed (%s): %v", m.Name, m.ID, reason, err)
+	return CheckResult{Status: models.MajorOutage, Latency: time.Since(startTime), Reason: reason, Error: err.Error(), Timings: timings, Evidence: evidence}
+}
+
+// truncate limita o tamanho de textos gravados no histórico.
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max] + "..."
+}